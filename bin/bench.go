@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"github.com/ryderlewis/aoc2021/pkg/bench"
+	"github.com/ryderlewis/aoc2021/pkg/challenge"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// runBench benchmarks every task n times, writing a summary to w. If out isn't empty, the
+// individual samples are also written to that file in the Go benchmark format.
+func runBench(w io.Writer, tasks []task, n int, out string) error {
+	reports := make([]*bench.Report, 0, len(tasks))
+
+	for _, t := range tasks {
+		year, day := t.year, t.day
+		if _, err := challenge.Lookup(year, day); err != nil {
+			return err
+		}
+		factory := func() challenge.DailyChallenge {
+			dc, _ := challenge.Lookup(year, day)
+			return dc
+		}
+
+		f, err := openInput(t)
+		if err != nil {
+			return err
+		}
+		input, err := io.ReadAll(f)
+		f.Close()
+		if err != nil {
+			return err
+		}
+
+		report, err := bench.Run(factory, t.day, t.part, filepath.Base(t.input()), input, n)
+		if err != nil {
+			return fmt.Errorf("day %d, challenge %d, %s: %w", t.day, t.part, t.input(), err)
+		}
+		reports = append(reports, report)
+	}
+
+	if err := bench.WriteText(w, reports); err != nil {
+		return err
+	}
+
+	if out == "" {
+		return nil
+	}
+
+	f, err := os.Create(out)
+	if err != nil {
+		return err
+	}
+	if err := bench.WriteBenchfmt(f, reports); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"github.com/ryderlewis/aoc2021/pkg/challenge"
+	"github.com/ryderlewis/aoc2021/pkg/fetch"
+	"github.com/ryderlewis/aoc2021/pkg/inputs"
+	"os"
+)
+
+// runFetch downloads real puzzle inputs into the inputs directory, skipping any that are
+// already up to date
+func runFetch(args []string) int {
+	fs := flag.NewFlagSet("fetch", flag.ExitOnError)
+	year := yearFlag(fs)
+	plugins := pluginsFlag(fs)
+	dayList := fs.String("day", "", "Day number or range to fetch, such as 1-10. Defaults to every day")
+	dir := fs.String("dir", inputs.DefaultDir, "Directory to save inputs in, under a directory for each year")
+	session := fs.String("session", "", "Session token from the site's cookie. Defaults to $"+fetch.SessionEnv)
+	baseURL := fs.String("url", fetch.DefaultBaseURL, "Site to download inputs from")
+	interval := fs.Duration("interval", fetch.DefaultInterval, "Least time to wait between requests")
+	_ = fs.Parse(args)
+
+	if err := loadPlugins(*plugins); err != nil {
+		fmt.Println(err)
+		return 2
+	}
+	if err := checkYear(*year); err != nil {
+		fmt.Println(err)
+		fs.Usage()
+		return 2
+	}
+
+	days := challenge.Days(*year)
+	if *dayList != "" {
+		var err error
+		if days, err = parseDays(*dayList); err != nil {
+			fmt.Println(err)
+			fs.Usage()
+			return 2
+		}
+	}
+
+	if *session == "" {
+		*session = os.Getenv(fetch.SessionEnv)
+	}
+	if *session == "" {
+		fmt.Printf("No session token: set $%s or use -session\n", fetch.SessionEnv)
+		return 2
+	}
+
+	c := fetch.New(*session, *dir)
+	c.BaseURL = *baseURL
+	c.Interval = *interval
+
+	failures := 0
+	for _, day := range days {
+		result, err := c.Fetch(context.Background(), *year, day)
+		switch {
+		case err != nil:
+			fmt.Printf("Day %d: Error: %v\n", day, err)
+			failures++
+		case result.Downloaded:
+			fmt.Printf("Day %d: downloaded %s\n", day, result.Path)
+		default:
+			fmt.Printf("Day %d: %s is up to date\n", day, result.Path)
+		}
+	}
+
+	if failures > 0 {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/ryderlewis/aoc2021/pkg/gen"
+	"os"
+)
+
+// runGen writes a randomly generated puzzle input for one day, for stress testing the
+// challenges on inputs of any size
+func runGen(args []string) int {
+	fs := flag.NewFlagSet("gen", flag.ExitOnError)
+	day := fs.Int("day", 0, "Day number, 1 through 25")
+	seed := fs.Int64("seed", 1, "Random seed. The same seed and scale always give the same input")
+	scale := fs.Int("scale", 0, "Size of the input, as listed below. 0 is puzzle sized")
+	out := fs.String("o", "-", "File to write the input to, or - for stdout")
+	answers := fs.Bool("answers", false, "Print the answers to stderr, for days where they are known")
+	fs.Usage = func() {
+		w := fs.Output()
+		fmt.Fprintf(w, "Usage of gen:\n")
+		fs.PrintDefaults()
+		fmt.Fprintf(w, "\nScale by day:\n")
+		for _, d := range gen.Days() {
+			def, about, _ := gen.DefaultScale(d)
+			fmt.Fprintf(w, "  %2d  %s, default %d\n", d, about, def)
+		}
+	}
+	_ = fs.Parse(args)
+
+	if *scale < 0 {
+		fs.Usage()
+		return 2
+	}
+	if _, _, err := gen.DefaultScale(*day); err != nil {
+		fs.Usage()
+		return 2
+	}
+
+	input, err := gen.Generate(*day, gen.Options{Seed: *seed, Scale: *scale})
+	if err != nil {
+		fmt.Printf("Day %d: Error: %v\n", *day, err)
+		return 1
+	}
+
+	if *out == "-" {
+		_, err = os.Stdout.Write(input.Text)
+	} else {
+		err = os.WriteFile(*out, input.Text, 0644)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Day %d: Error: %v\n", *day, err)
+		return 1
+	}
+
+	if *answers {
+		for part := 1; part <= 2; part++ {
+			if answer, ok := input.Answers[part]; ok {
+				fmt.Fprintf(os.Stderr, "Day %d, challenge %d: %s\n", *day, part, answer)
+			}
+		}
+	}
+
+	return 0
+}
@@ -1,134 +1,287 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"github.com/ryderlewis/aoc2021/pkg/challenge"
-	"github.com/ryderlewis/aoc2021/pkg/day01"
-	"github.com/ryderlewis/aoc2021/pkg/day02"
-	"github.com/ryderlewis/aoc2021/pkg/day03"
-	"github.com/ryderlewis/aoc2021/pkg/day04"
-	"github.com/ryderlewis/aoc2021/pkg/day05"
-	"github.com/ryderlewis/aoc2021/pkg/day06"
-	"github.com/ryderlewis/aoc2021/pkg/day07"
-	"github.com/ryderlewis/aoc2021/pkg/day08"
-	"github.com/ryderlewis/aoc2021/pkg/day09"
-	"github.com/ryderlewis/aoc2021/pkg/day10"
-	"github.com/ryderlewis/aoc2021/pkg/day11"
-	"github.com/ryderlewis/aoc2021/pkg/day12"
-	"github.com/ryderlewis/aoc2021/pkg/day13"
-	"github.com/ryderlewis/aoc2021/pkg/day14"
-	"github.com/ryderlewis/aoc2021/pkg/day15"
-	"github.com/ryderlewis/aoc2021/pkg/day16"
-	"github.com/ryderlewis/aoc2021/pkg/day17"
-	"github.com/ryderlewis/aoc2021/pkg/day18"
-	"github.com/ryderlewis/aoc2021/pkg/day19"
-	"github.com/ryderlewis/aoc2021/pkg/day20"
-	"github.com/ryderlewis/aoc2021/pkg/day21"
-	"github.com/ryderlewis/aoc2021/pkg/day22"
-	"github.com/ryderlewis/aoc2021/pkg/day23"
-	"github.com/ryderlewis/aoc2021/pkg/day24"
-	"github.com/ryderlewis/aoc2021/pkg/day25"
-	"io"
+	"github.com/ryderlewis/aoc2021/pkg/inputs"
+	"github.com/ryderlewis/aoc2021/pkg/plugin"
+	_ "github.com/ryderlewis/aoc2021/pkg/solutions"
+	"github.com/ryderlewis/aoc2021/pkg/viz"
 	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 )
 
+// defaultYear is the year run when no -year is given
+const defaultYear = 2021
+
+// commands are run as "bin <command> [flags]", and return the exit status
+var commands = map[string]func(args []string) int{
+	"fetch":  runFetch,
+	"gen":    runGen,
+	"serve":  runServe,
+	"submit": runSubmit,
+}
+
 func main() {
-	day := flag.Int("day", 0, "Day number, 1 through 25")
-	chnum := flag.Int("challenge", 0, "Challenge number, 1 or 2")
-	fname := flag.String("filename", "", "File with input values")
+	if len(os.Args) > 1 {
+		if command, ok := commands[os.Args[1]]; ok {
+			os.Exit(command(os.Args[2:]))
+		}
+	}
 
+	year := yearFlag(flag.CommandLine)
+	plugins := pluginsFlag(flag.CommandLine)
+	dayList := flag.String("day", "", "Day number, 1 through 25, or a range such as 1-10 or 1-3,7")
+	chnum := flag.Int("challenge", 0, "Challenge number, 1 or 2. When running several days, 0 runs both")
+	fname := flag.String("filename", "", "File with input values, or - for stdin. Defaults to the day's input in the inputs directory")
+	inputDir := flag.String("inputs", inputs.DefaultDir, "Directory holding the inputs, in a directory for each year")
+	example := flag.Bool("example", false, "Run the example input, aNN.txt, instead of the real one. Example files holding several examples run each of them")
+	exampleName := flag.String("example-name", "", "Run only the example with this name, from an example file holding several")
+	all := flag.Bool("all", false, "Run every day and both challenges against the files in the inputs directory")
+	timeout := flag.Duration("timeout", 0, "Abort each challenge after this long, e.g. 30s. 0 means no limit")
+	format := flag.String("format", textFormat, "Output format, text or json")
+	jobs := flag.Int("j", 1, "Number of challenges to run at once when running several")
+	benchRuns := flag.Int("bench", 0, "Benchmark the selected challenges by solving each this many times")
+	benchOut := flag.String("bench-out", "", "With -bench, also write every sample to this file in Go benchmark format")
+	vizOut := flag.String("viz", "", "Record the challenge as an animation, saved to this .gif file, or a .png of the final frame")
+	vizScale := flag.Int("viz-scale", 4, "With -viz, the size of each grid cell in pixels")
+	vizDelay := flag.Int("viz-delay", 10, "With -viz, the time between frames in hundredths of a second")
+	vizPalette := flag.String("viz-palette", "heat", "With -viz, a palette name, one of "+strings.Join(viz.PaletteNames(), ", ")+", or a comma separated list of hex colors")
+	verbose := flag.Bool("v", false, "Write what each challenge finds along the way to stderr, or the -trace-out file")
+	traceTopics := flag.String("trace", "", "Write every step of these days, such as day04,day23, optionally with a level such as day23:info")
+	traceOut := flag.String("trace-out", "", "Write -v and -trace output to this file instead of stderr")
+	explain := flag.Bool("explain", false, "Explain step by step how the answer was found, for the days that can")
+	watch := flag.Bool("watch", false, "Animate the challenge in the terminal. Keys: space pauses, n steps, + and - change speed, q stops watching")
+	watchDelay := flag.Duration("watch-delay", 100*time.Millisecond, "With -watch, the time between frames")
+
+	flag.Usage = usage
 	flag.Parse()
 
-	if *day < 1 || *day > 25 || *chnum < 1 || *chnum > 2 {
+	if err := loadPlugins(*plugins); err != nil {
+		fmt.Println(err)
+		os.Exit(2)
+	}
+	if err := checkYear(*year); err != nil {
+		fmt.Println(err)
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var days []int
+	if *all {
+		days = challenge.Days(*year)
+	} else if *dayList != "" {
+		var err error
+		if days, err = parseDays(*dayList); err != nil {
+			fmt.Println(err)
+			flag.Usage()
+			os.Exit(2)
+		}
+	}
+
+	if len(days) == 0 || *chnum < 0 || *chnum > 2 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if *jobs < 1 {
+		fmt.Println("-j must be at least 1")
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if *format != textFormat && *format != jsonFormat {
+		fmt.Printf("Unknown format: %s\n", *format)
 		flag.Usage()
+		os.Exit(2)
 	}
 
-	var input io.Reader
-	if *fname == "" || *fname == "-" {
-		input = os.Stdin
+	var tasks []task
+	if *all || len(days) > 1 {
+		if *fname != "" || *exampleName != "" {
+			fmt.Println("-filename and -example-name pick the input of a single day")
+			flag.Usage()
+			os.Exit(2)
+		}
+
+		parts := []int{1, 2}
+		if *chnum != 0 {
+			parts = []int{*chnum}
+		}
+		tasks = buildTasks(*inputDir, *year, days, parts, *example)
 	} else {
-		f, err := os.Open(*fname)
+		if *chnum == 0 {
+			flag.Usage()
+			os.Exit(2)
+		}
+
+		t := task{year: *year, day: days[0], part: *chnum, example: *example, filename: *fname, name: *exampleName}
+		switch t.filename {
+		case "-":
+		case "":
+			path, err := inputs.Find(*inputDir, *year, t.day, *example)
+			if err != nil {
+				fmt.Println(err)
+				if !*example {
+					fmt.Printf("Download it with: %s fetch -year %d -day %d\n", filepath.Base(os.Args[0]), *year, t.day)
+				}
+				os.Exit(1)
+			}
+			t.filename = path
+		default:
+			if _, err := os.Stat(t.filename); err != nil {
+				fmt.Printf("Couldn't open %v: %v\n", t.filename, err)
+				flag.Usage()
+				os.Exit(2)
+			}
+		}
+		tasks = []task{t}
+	}
+
+	var err error
+	if tasks, err = expandExamples(tasks); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	if *explain {
+		if len(tasks) != 1 || *benchRuns > 0 || *vizOut != "" || *watch {
+			fmt.Println("-explain explains a single day and challenge, without -bench, -viz or -watch")
+			flag.Usage()
+			os.Exit(2)
+		}
+		tasks[0].explain = true
+	}
+
+	if *verbose || *traceTopics != "" {
+		log, err := openTrace(*verbose, *traceTopics, *traceOut)
 		if err != nil {
-			fmt.Printf("Couldn't open %v: %v\n", *fname, err)
+			fmt.Println(err)
 			flag.Usage()
+			os.Exit(2)
+		}
+		for i := range tasks {
+			tasks[i].log = log
 		}
+	}
 
-		input = f
-		defer f.Close()
-	}
-
-	var dc challenge.DailyChallenge
-	var answer string
-
-	switch *day {
-	case 1:
-		dc = &day01.Runner{}
-	case 2:
-		dc = &day02.Runner{}
-	case 3:
-		dc = &day03.Runner{}
-	case 4:
-		dc = &day04.Runner{}
-	case 5:
-		dc = &day05.Runner{}
-	case 6:
-		dc = &day06.Runner{}
-	case 7:
-		dc = &day07.Runner{}
-	case 8:
-		dc = &day08.Runner{}
-	case 9:
-		dc = &day09.Runner{}
-	case 10:
-		dc = &day10.Runner{}
-	case 11:
-		dc = &day11.Runner{}
-	case 12:
-		dc = &day12.Runner{}
-	case 13:
-		dc = &day13.Runner{}
-	case 14:
-		dc = &day14.Runner{}
-	case 15:
-		dc = &day15.Runner{}
-	case 16:
-		dc = &day16.Runner{}
-	case 17:
-		dc = &day17.Runner{}
-	case 18:
-		dc = &day18.Runner{}
-	case 19:
-		dc = &day19.Runner{}
-	case 20:
-		dc = &day20.Runner{}
-	case 21:
-		dc = &day21.Runner{}
-	case 22:
-		dc = &day22.Runner{}
-	case 23:
-		dc = &day23.Runner{}
-	case 24:
-		dc = &day24.Runner{}
-	case 25:
-		dc = &day25.Runner{}
-	}
-
-	if dc == nil {
-		fmt.Printf("Day not implemented: %d\n", *day)
+	var rec *viz.Recorder
+	stopWatch := func() {}
+	if *vizOut != "" && *watch {
+		fmt.Println("-viz and -watch can't be combined")
 		flag.Usage()
+		os.Exit(2)
+	}
+	if *vizOut != "" || *watch {
+		if len(tasks) != 1 || *benchRuns > 0 {
+			fmt.Println("-viz and -watch animate a single day and challenge")
+			flag.Usage()
+			os.Exit(2)
+		}
+	}
+	if *watch {
+		rec, stopWatch = startWatch(*watchDelay)
+		tasks[0].rec = rec
+	} else if *vizOut != "" {
+		palette, err := viz.ParsePalette(*vizPalette)
+		if err != nil {
+			fmt.Println(err)
+			flag.Usage()
+			os.Exit(2)
+		}
+		rec = viz.New(viz.Options{Scale: *vizScale, Delay: *vizDelay, Palette: palette})
+		tasks[0].rec = rec
 	}
 
-	var err error
-	if *chnum == 1 {
-		answer, err = dc.Challenge1(input)
-	} else {
-		answer, err = dc.Challenge2(input)
+	if *benchRuns > 0 {
+		if err := runBench(os.Stdout, tasks, *benchRuns, *benchOut); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
 	}
 
-	if err == nil {
-		fmt.Printf("Day %d, challenge %d: %s\n", *day, *chnum, answer)
-	} else {
-		fmt.Printf("Error: %v\n", err)
+	outcomes := runTasks(tasks, *timeout, *jobs)
+	stopWatch() // put the terminal back before writing anything else
+
+	switch {
+	case *format == jsonFormat:
+		if err := writeJSON(os.Stdout, outcomes); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	case len(outcomes) == 1 && !*all:
+		writeOutcome(os.Stdout, outcomes[0])
+	default:
+		writeSummary(os.Stdout, outcomes)
+	}
+
+	if failures(outcomes) > 0 {
+		os.Exit(1)
+	}
+
+	if *vizOut != "" {
+		if err := rec.Save(*vizOut); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Fprintf(os.Stderr, "Wrote %d frames to %s\n", rec.Len(), *vizOut)
+	}
+}
+
+// yearFlag defines the -year flag on fs
+func yearFlag(fs *flag.FlagSet) *int {
+	return fs.Int("year", defaultYear, "Puzzle year, such as 2021")
+}
+
+// pluginsFlag defines the -plugins flag on fs
+func pluginsFlag(fs *flag.FlagSet) *string {
+	return fs.String("plugins", plugin.DefaultConfig, "Config file listing the days solved by external programs")
+}
+
+// loadPlugins registers the days solved by external programs listed in the config file at
+// path. The default config file needn't exist.
+func loadPlugins(path string) error {
+	err := plugin.RegisterFile(path)
+	if errors.Is(err, os.ErrNotExist) && path == plugin.DefaultConfig {
+		return nil
+	}
+	return err
+}
+
+// checkYear makes sure there are challenges for year
+func checkYear(year int) error {
+	if len(challenge.Days(year)) > 0 {
+		return nil
+	}
+
+	years := make([]string, 0)
+	for _, y := range challenge.Years() {
+		years = append(years, strconv.Itoa(y))
+	}
+	return fmt.Errorf("No challenges for %d, only for %s", year, strings.Join(years, ", "))
+}
+
+func usage() {
+	out := flag.CommandLine.Output()
+	name := filepath.Base(os.Args[0])
+
+	names := make([]string, 0, len(commands))
+	for command := range commands {
+		names = append(names, command)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintf(out, "Usage: %s [flags]\n", name)
+	for _, command := range names {
+		fmt.Fprintf(out, "       %s %s [flags]\n", name, command)
 	}
+	fmt.Fprintln(out, "\nFlags:")
+	flag.PrintDefaults()
 }
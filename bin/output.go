@@ -0,0 +1,123 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/ryderlewis/aoc2021/pkg/challenge"
+	"io"
+	"path/filepath"
+	"strconv"
+	"text/tabwriter"
+	"time"
+)
+
+const (
+	textFormat = "text"
+	jsonFormat = "json"
+)
+
+// report is the JSON representation of an outcome
+type report struct {
+	Year        int                  `json:"year"`
+	Day         int                  `json:"day"`
+	Part        int                  `json:"part"`
+	Input       string               `json:"input"`
+	Answer      string               `json:"answer"`
+	Error       string               `json:"error,omitempty"`
+	DurationNS  int64                `json:"duration_ns"`
+	Artifacts   []challenge.Artifact `json:"artifacts,omitempty"`
+	Explanation []*challenge.Step    `json:"explanation,omitempty"`
+}
+
+func newReport(o outcome) report {
+	r := report{
+		Year:       o.year,
+		Day:        o.day,
+		Part:       o.part,
+		Input:      o.input(),
+		DurationNS: o.elapsed.Nanoseconds(),
+	}
+	if o.result != nil {
+		r.Answer = o.result.Answer
+		r.Artifacts = o.result.Artifacts
+	}
+	if o.explanation != nil {
+		r.Explanation = o.explanation.Steps
+	}
+	if o.err != nil {
+		r.Error = o.err.Error()
+	}
+
+	return r
+}
+
+// writeJSON writes each outcome to w as a JSON object on its own line
+func writeJSON(w io.Writer, outcomes []outcome) error {
+	enc := json.NewEncoder(w)
+	for _, o := range outcomes {
+		if err := enc.Encode(newReport(o)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeOutcome writes a single outcome to w as text, followed by its artifacts and how
+// it was found
+func writeOutcome(w io.Writer, o outcome) {
+	if o.err != nil {
+		fmt.Fprintf(w, "Error: %v\n", o.err)
+		return
+	}
+
+	fmt.Fprintf(w, "Day %d, challenge %d: %s\n", o.day, o.part, o.result.Answer)
+	for _, a := range o.result.Artifacts {
+		if a.Kind == challenge.StatArtifact {
+			fmt.Fprintf(w, "%s: %s\n", a.Name, a.Value)
+		} else {
+			fmt.Fprintf(w, "%s:\n%s\n", a.Name, a.Value)
+		}
+	}
+
+	if o.explanation != nil {
+		writeExplanation(w, o.explanation)
+	}
+}
+
+// writeExplanation writes the steps of an explanation to w as a numbered list, with the
+// details of each indented below it
+func writeExplanation(w io.Writer, e *challenge.Explanation) {
+	fmt.Fprintln(w, "How it was found:")
+	width := len(strconv.Itoa(len(e.Steps)))
+	for i, s := range e.Steps {
+		fmt.Fprintf(w, "%*d. %s\n", width, i+1, s.Text)
+		for _, d := range s.Details {
+			fmt.Fprintf(w, "%*s%s\n", width+2, "", d)
+		}
+	}
+}
+
+// writeSummary writes a table of outcomes to w
+func writeSummary(w io.Writer, outcomes []outcome) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "DAY\tPART\tINPUT\tANSWER\tTIME\tERROR")
+
+	var total time.Duration
+	for _, o := range outcomes {
+		answer, errText := "", ""
+		if o.result != nil {
+			answer = o.result.Answer
+		}
+		if o.err != nil {
+			errText = o.err.Error()
+		}
+		total += o.elapsed
+
+		fmt.Fprintf(tw, "%d\t%d\t%s\t%s\t%s\t%s\n",
+			o.day, o.part, filepath.Base(o.input()), answer, o.elapsed.Round(time.Microsecond), errText)
+	}
+	tw.Flush()
+
+	fmt.Fprintf(w, "\n%d runs, %d errors, %s total\n", len(outcomes), failures(outcomes), total.Round(time.Millisecond))
+}
@@ -0,0 +1,272 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ryderlewis/aoc2021/pkg/challenge"
+	"github.com/ryderlewis/aoc2021/pkg/inputs"
+	"github.com/ryderlewis/aoc2021/pkg/trace"
+	"github.com/ryderlewis/aoc2021/pkg/viz"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// task is a single year, day, part and input file to run
+type task struct {
+	year     int
+	day      int
+	part     int
+	example  bool
+	filename string
+	name     string        // the example to run from an example file holding several, if set
+	rec      *viz.Recorder // records the solve as an animation, if set
+	log      *trace.Log    // diagnostics from the solve go here, if set
+	explain  bool          // whether to explain how the answer was found
+}
+
+// outcome is the result of running a task
+type outcome struct {
+	task
+	result      *challenge.Result
+	explanation *challenge.Explanation // how the answer was found, if asked
+	err         error
+	elapsed     time.Duration
+}
+
+// parseDays parses a day list such as "5", "1-10" or "1-3,7,20-25"
+func parseDays(s string) ([]int, error) {
+	days := make([]int, 0)
+
+	for _, field := range strings.Split(s, ",") {
+		field = strings.TrimSpace(field)
+		bounds := strings.SplitN(field, "-", 2)
+
+		first, err := strconv.Atoi(bounds[0])
+		if err != nil {
+			return nil, fmt.Errorf("Invalid day %q", field)
+		}
+		last := first
+		if len(bounds) == 2 {
+			if last, err = strconv.Atoi(bounds[1]); err != nil {
+				return nil, fmt.Errorf("Invalid day range %q", field)
+			}
+		}
+
+		if first < 1 || last > 25 || first > last {
+			return nil, fmt.Errorf("Invalid day range %q", field)
+		}
+
+		for d := first; d <= last; d++ {
+			days = append(days, d)
+		}
+	}
+
+	return days, nil
+}
+
+// buildTasks returns a task for every combination of day, part and input file in a year,
+// running only the example inputs if examplesOnly is set
+func buildTasks(dir string, year int, days, parts []int, examplesOnly bool) []task {
+	kinds := []bool{true, false}
+	if examplesOnly {
+		kinds = []bool{true}
+	}
+	tasks := make([]task, 0, len(days)*len(parts)*len(kinds))
+
+	for _, day := range days {
+		for _, part := range parts {
+			for _, example := range kinds {
+				tasks = append(tasks, task{
+					year:     year,
+					day:      day,
+					part:     part,
+					example:  example,
+					filename: inputs.Path(dir, year, day, example),
+				})
+			}
+		}
+	}
+
+	return tasks
+}
+
+// expandExamples replaces each task running an example file that holds several examples
+// with a task for each of them
+func expandExamples(tasks []task) ([]task, error) {
+	expanded := make([]task, 0, len(tasks))
+
+	for _, t := range tasks {
+		if !t.example || t.name != "" || t.filename == "-" {
+			expanded = append(expanded, t)
+			continue
+		}
+
+		examples, err := inputs.ReadExamples(t.filename)
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+			// reported when the task is run
+			expanded = append(expanded, t)
+			continue
+		case err != nil:
+			return nil, err
+		}
+
+		for _, ex := range examples {
+			t.name = ex.Name
+			expanded = append(expanded, t)
+		}
+	}
+
+	return expanded, nil
+}
+
+// input names the task's puzzle input, including the example within the file if set
+func (t task) input() string {
+	if t.name == "" {
+		return t.filename
+	}
+	return t.filename + "#" + t.name
+}
+
+// openInput opens the task's puzzle input. A filename of "-" reads from stdin.
+func openInput(t task) (io.ReadCloser, error) {
+	if t.name == "" {
+		if t.filename == "-" {
+			return io.NopCloser(os.Stdin), nil
+		}
+		return os.Open(t.filename)
+	}
+
+	var data []byte
+	var err error
+	if t.filename == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(t.filename)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	examples, err := inputs.SplitExamples(data)
+	if err == nil {
+		var ex inputs.Example
+		if ex, err = inputs.FindExample(examples, t.name); err == nil {
+			return io.NopCloser(bytes.NewReader(ex.Text)), nil
+		}
+	}
+	return nil, fmt.Errorf("%s: %w", t.filename, err)
+}
+
+// runTask runs a task against a fresh instance of the day's challenge, giving up after
+// timeout if it is non-zero
+func runTask(t task, timeout time.Duration) outcome {
+	input, err := openInput(t)
+	if err != nil {
+		return outcome{task: t, err: err}
+	}
+	defer input.Close()
+
+	return runInput(t, input, timeout)
+}
+
+// runInput runs a task against a fresh instance of the day's challenge, reading the
+// puzzle input from input rather than the task's file
+func runInput(t task, input io.Reader, timeout time.Duration) outcome {
+	o := outcome{task: t}
+
+	dc, err := challenge.Lookup(t.year, t.day)
+	if err != nil {
+		o.err = err
+		return o
+	}
+	if tc, ok := dc.(trace.Traced); ok {
+		tc.SetTracer(t.log.Tracer(fmt.Sprintf("day%02d", t.day)))
+	}
+
+	start := time.Now()
+	o.result, o.explanation, o.err = solve(dc, t, input, timeout)
+	o.elapsed = time.Since(start)
+
+	return o
+}
+
+// solve runs the task's part of a challenge, giving up after timeout if it is non-zero.
+// If the task records or explains the solve, only the answer is kept as the result.
+func solve(dc challenge.DailyChallenge, t task, input io.Reader, timeout time.Duration) (*challenge.Result, *challenge.Explanation, error) {
+	ctx := context.Background()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	var result *challenge.Result
+	var explanation *challenge.Explanation
+	var err error
+	switch {
+	case t.rec != nil:
+		var answer string
+		if answer, err = viz.Solve(ctx, dc, t.part, input, t.rec); err == nil {
+			result = &challenge.Result{Answer: answer}
+		}
+	case t.explain:
+		if explanation, err = challenge.Explain(ctx, dc, t.part, input); err == nil {
+			result = &challenge.Result{Answer: explanation.Answer}
+		}
+	default:
+		result, err = challenge.Solve(ctx, dc, t.part, input)
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		err = fmt.Errorf("timed out after %s", timeout)
+	}
+
+	return result, explanation, err
+}
+
+// runTasks runs every task, up to workers of them at once, and returns their outcomes in
+// the same order as the tasks. Each task gets its own instance of its challenge.
+func runTasks(tasks []task, timeout time.Duration, workers int) []outcome {
+	outcomes := make([]outcome, len(tasks))
+	if workers > len(tasks) {
+		workers = len(tasks)
+	}
+
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				outcomes[i] = runTask(tasks[i], timeout)
+			}
+		}()
+	}
+
+	for i := range tasks {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	return outcomes
+}
+
+// failures counts the outcomes that ended in an error
+func failures(outcomes []outcome) int {
+	count := 0
+	for _, o := range outcomes {
+		if o.err != nil {
+			count++
+		}
+	}
+
+	return count
+}
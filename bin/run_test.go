@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"github.com/ryderlewis/aoc2021/pkg/challenge"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// testYear holds the fake challenge below, so the real ones needn't be run
+const testYear = 1999
+
+// napper sleeps for the number of milliseconds in its input, then answers with it. Each
+// instance refuses to solve more than once, to show that no task shares one.
+type napper struct {
+	solved bool
+}
+
+// naps counts the napper instances made
+var naps int32
+
+func (n *napper) Challenge1(input io.Reader) (string, error) {
+	if n.solved {
+		return "", fmt.Errorf("instance solved twice")
+	}
+	n.solved = true
+
+	b, err := io.ReadAll(input)
+	if err != nil {
+		return "", err
+	}
+	ms, err := strconv.Atoi(strings.TrimSpace(string(b)))
+	if err != nil {
+		return "", err
+	}
+	time.Sleep(time.Duration(ms) * time.Millisecond)
+
+	return strconv.Itoa(ms), nil
+}
+
+func (n *napper) Challenge2(input io.Reader) (string, error) {
+	return n.Challenge1(input)
+}
+
+func init() {
+	challenge.Register(testYear, 1, func() challenge.DailyChallenge {
+		atomic.AddInt32(&naps, 1)
+		return &napper{}
+	})
+}
+
+func TestRunTasks(t *testing.T) {
+	dir := t.TempDir()
+
+	// the later tasks finish first
+	delays := []int{60, 45, 30, 15, 0, 0}
+	tasks := make([]task, len(delays))
+	for i, ms := range delays {
+		filename := filepath.Join(dir, fmt.Sprintf("nap%d.txt", i))
+		if err := os.WriteFile(filename, []byte(strconv.Itoa(ms)), 0644); err != nil {
+			t.Fatal(err)
+		}
+		tasks[i] = task{year: testYear, day: 1, part: i%2 + 1, filename: filename}
+	}
+
+	for _, workers := range []int{1, 3, 10} {
+		before := atomic.LoadInt32(&naps)
+		outcomes := runTasks(tasks, 0, workers)
+
+		if len(outcomes) != len(tasks) {
+			t.Fatalf("-j %d: got %d outcomes, want %d", workers, len(outcomes), len(tasks))
+		}
+		for i, o := range outcomes {
+			if o.err != nil {
+				t.Errorf("-j %d: task %d: %v", workers, i, o.err)
+				continue
+			}
+			if o.filename != tasks[i].filename || o.part != tasks[i].part || o.result.Answer != strconv.Itoa(delays[i]) {
+				t.Errorf("-j %d: outcome %d is for %s part %d answering %s, want %s part %d", workers, i, o.filename, o.part, o.result.Answer, tasks[i].filename, tasks[i].part)
+			}
+		}
+		if made := atomic.LoadInt32(&naps) - before; made != int32(len(tasks)) {
+			t.Errorf("-j %d: made %d instances for %d tasks", workers, made, len(tasks))
+		}
+	}
+}
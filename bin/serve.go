@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/ryderlewis/aoc2021/pkg/serve"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+)
+
+// runServe serves the challenges as a JSON API until interrupted, then lets the requests
+// in progress finish
+func runServe(args []string) int {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	year := yearFlag(fs)
+	plugins := pluginsFlag(fs)
+	addr := fs.String("addr", "localhost:8080", "Address to listen on")
+	maxInput := fs.Int64("max-input", serve.DefaultMaxInput, "Largest puzzle input accepted, in bytes")
+	timeout := fs.Duration("timeout", serve.DefaultTimeout, "Abort each solve after this long")
+	concurrency := fs.Int("concurrency", serve.DefaultConcurrency, "Most solves to run at once. Requests beyond it are turned away.")
+	_ = fs.Parse(args)
+
+	if *maxInput <= 0 || *timeout <= 0 || *concurrency <= 0 || fs.NArg() > 0 {
+		fs.Usage()
+		return 2
+	}
+	if err := loadPlugins(*plugins); err != nil {
+		fmt.Println(err)
+		return 2
+	}
+	if err := checkYear(*year); err != nil {
+		fmt.Println(err)
+		fs.Usage()
+		return 2
+	}
+
+	srv := &http.Server{
+		Addr: *addr,
+		Handler: serve.New(serve.Options{
+			Year:        *year,
+			MaxInput:    *maxInput,
+			Timeout:     *timeout,
+			Concurrency: *concurrency,
+		}),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	interrupted := make(chan os.Signal, 1)
+	signal.Notify(interrupted, os.Interrupt)
+	stopped := make(chan error, 1)
+	go func() {
+		<-interrupted
+		ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+		defer cancel()
+		stopped <- srv.Shutdown(ctx)
+	}()
+
+	fmt.Fprintf(os.Stderr, "Serving on %s\n", *addr)
+	if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		fmt.Printf("Error: %v\n", err)
+		return 1
+	}
+	if err := <-stopped; err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"github.com/ryderlewis/aoc2021/pkg/fetch"
+	"github.com/ryderlewis/aoc2021/pkg/inputs"
+	"github.com/ryderlewis/aoc2021/pkg/submit"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// runSubmit solves one part of a day against the real input and submits the answer,
+// recording the verdict in the ledger. It exits 0 only if the answer was right.
+func runSubmit(args []string) int {
+	fs := flag.NewFlagSet("submit", flag.ExitOnError)
+	year := yearFlag(fs)
+	plugins := pluginsFlag(fs)
+	day := fs.Int("day", 0, "Day number, 1 through 25")
+	chnum := fs.Int("challenge", 0, "Challenge number, 1 or 2")
+	fname := fs.String("filename", "", "File with input values. Defaults to the real input in the inputs directory")
+	inputDir := fs.String("inputs", inputs.DefaultDir, "Directory holding the inputs, in a directory for each year")
+	answer := fs.String("answer", "", "Answer to submit instead of solving the challenge")
+	ledgerPath := fs.String("ledger", filepath.Join(inputs.DefaultDir, submit.LedgerFile), "File recording every submitted answer")
+	session := fs.String("session", "", "Session token from the site's cookie. Defaults to $"+fetch.SessionEnv)
+	baseURL := fs.String("url", submit.DefaultBaseURL, "Site to submit answers to")
+	timeout := fs.Duration("timeout", 0, "Abort the challenge after this long, e.g. 30s. 0 means no limit")
+	_ = fs.Parse(args)
+
+	if *day < 1 || *day > 25 || *chnum < 1 || *chnum > 2 {
+		fs.Usage()
+		return 2
+	}
+	if err := loadPlugins(*plugins); err != nil {
+		fmt.Println(err)
+		return 2
+	}
+	if err := checkYear(*year); err != nil {
+		fmt.Println(err)
+		fs.Usage()
+		return 2
+	}
+
+	if *session == "" {
+		*session = os.Getenv(fetch.SessionEnv)
+	}
+	if *session == "" {
+		fmt.Printf("No session token: set $%s or use -session\n", fetch.SessionEnv)
+		return 2
+	}
+
+	if *answer == "" {
+		t := task{year: *year, day: *day, part: *chnum, filename: *fname}
+		if t.filename == "" {
+			path, err := inputs.Find(*inputDir, *year, *day, false)
+			if err != nil {
+				fmt.Println(err)
+				return 1
+			}
+			t.filename = path
+		}
+
+		o := runTask(t, *timeout)
+		if o.err != nil {
+			fmt.Printf("Day %d: Error: %v\n", *day, o.err)
+			return 1
+		}
+		*answer = o.result.Answer
+	}
+
+	ledger, err := submit.LoadLedger(*ledgerPath)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return 1
+	}
+
+	c := submit.New(*session, ledger)
+	c.BaseURL = *baseURL
+
+	attempt, err := c.Submit(context.Background(), *year, *day, *chnum, *answer)
+	if err != nil {
+		fmt.Printf("Day %d, challenge %d: %s not submitted: %v\n", *day, *chnum, *answer, err)
+		return 1
+	}
+
+	fmt.Printf("Day %d, challenge %d: %s is %s\n", *day, *chnum, attempt.Answer, attempt.Verdict)
+	if attempt.Verdict == submit.RateLimited {
+		fmt.Printf("Try again after %s\n", attempt.Time.Add(attempt.Wait).Format(time.Kitchen))
+	}
+
+	if attempt.Verdict != submit.Correct {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"github.com/ryderlewis/aoc2021/pkg/trace"
+	"os"
+)
+
+// openTrace returns the log for diagnostics, showing every day at Info if verbose, and
+// the days listed in topics in more detail. It writes to stderr, or else to the file
+// named out, which stays open until the program exits.
+func openTrace(verbose bool, topics, out string) (*trace.Log, error) {
+	level := trace.Off
+	if verbose {
+		level = trace.Info
+	}
+
+	w := os.Stderr
+	if out != "" {
+		f, err := os.Create(out)
+		if err != nil {
+			return nil, err
+		}
+		w = f
+	}
+
+	log := trace.New(w, level)
+	if topics != "" {
+		if err := log.SetTopics(topics); err != nil {
+			return nil, err
+		}
+	}
+
+	return log, nil
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+	"github.com/ryderlewis/aoc2021/pkg/viz"
+	"os"
+	"os/signal"
+	"time"
+)
+
+// startWatch returns a recorder that draws the simulation in the terminal, reading keys
+// to control it from the terminal itself so that the input can still come from stdin.
+// stop puts the terminal back as it was.
+func startWatch(delay time.Duration) (rec *viz.Recorder, stop func()) {
+	opts := viz.WatchOptions{Delay: delay}
+	stop = func() {}
+
+	if tty, err := os.Open("/dev/tty"); err == nil {
+		opts.Keys = tty
+		if restore, err := viz.RawMode(tty); err == nil {
+			interrupted := make(chan os.Signal, 1)
+			signal.Notify(interrupted, os.Interrupt)
+			go func() {
+				if _, ok := <-interrupted; ok {
+					restore()
+					os.Exit(130)
+				}
+			}()
+
+			stop = func() {
+				signal.Stop(interrupted)
+				close(interrupted)
+				restore()
+			}
+		} else {
+			fmt.Fprintf(os.Stderr, "Keys take effect after enter: %v\n", err)
+		}
+	}
+
+	return viz.Watch(os.Stdout, opts), stop
+}
@@ -0,0 +1,184 @@
+// Package bench measures how long challenges take to solve, and how much they allocate.
+// Challenges that implement challenge.PhasedChallenge have their parsing and solving
+// measured separately.
+package bench
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/ryderlewis/aoc2021/pkg/challenge"
+	"io"
+	"runtime"
+	"sort"
+	"time"
+)
+
+const (
+	PhaseParse = "parse"
+	PhaseSolve = "solve"
+	PhaseTotal = "total"
+)
+
+// Sample is the measurement of one phase of one run
+type Sample struct {
+	Elapsed time.Duration
+	Allocs  uint64
+	Bytes   uint64
+}
+
+// Phase summarizes the samples taken for one phase across every run
+type Phase struct {
+	Name    string
+	Samples []Sample
+}
+
+// Report is the outcome of benchmarking one part of a challenge
+type Report struct {
+	Day    int
+	Part   int
+	Input  string
+	Answer string
+	Phases []*Phase // parse and solve phases are only present for phased challenges
+}
+
+// Run solves one part of a challenge n times, using a new instance from factory for every
+// run, and reports timings for each phase. Each run reads from its own copy of input.
+func Run(factory challenge.Factory, day, part int, name string, input []byte, n int) (*Report, error) {
+	if n < 1 {
+		return nil, fmt.Errorf("bench: invalid run count %d", n)
+	}
+
+	report := &Report{Day: day, Part: part, Input: name}
+	parse := &Phase{Name: PhaseParse}
+	solve := &Phase{Name: PhaseSolve}
+	total := &Phase{Name: PhaseTotal}
+
+	for i := 0; i < n; i++ {
+		dc := factory()
+		runtime.GC()
+
+		var answer string
+		var err error
+		if pc, ok := dc.(challenge.PhasedChallenge); ok {
+			var loadErr error
+			parseSample := measure(func() {
+				loadErr = pc.Load(bytes.NewReader(input))
+			})
+			if loadErr != nil {
+				return nil, loadErr
+			}
+
+			solveSample := measure(func() {
+				answer, err = solvePhased(pc, part)
+			})
+			parse.Samples = append(parse.Samples, parseSample)
+			solve.Samples = append(solve.Samples, solveSample)
+			total.Samples = append(total.Samples, Sample{
+				Elapsed: parseSample.Elapsed + solveSample.Elapsed,
+				Allocs:  parseSample.Allocs + solveSample.Allocs,
+				Bytes:   parseSample.Bytes + solveSample.Bytes,
+			})
+		} else {
+			total.Samples = append(total.Samples, measure(func() {
+				answer, err = solveWhole(dc, part, bytes.NewReader(input))
+			}))
+		}
+
+		if err != nil {
+			return nil, err
+		}
+		if i > 0 && answer != report.Answer {
+			return nil, fmt.Errorf("bench: run %d answered %q, earlier runs answered %q", i+1, answer, report.Answer)
+		}
+		report.Answer = answer
+	}
+
+	if len(parse.Samples) > 0 {
+		report.Phases = append(report.Phases, parse, solve)
+	}
+	report.Phases = append(report.Phases, total)
+
+	return report, nil
+}
+
+func solvePhased(pc challenge.PhasedChallenge, part int) (string, error) {
+	if part == 1 {
+		return pc.Solve1()
+	}
+	return pc.Solve2()
+}
+
+func solveWhole(dc challenge.DailyChallenge, part int, input io.Reader) (string, error) {
+	if part == 1 {
+		return dc.Challenge1(input)
+	}
+	return dc.Challenge2(input)
+}
+
+// measure times f and counts the heap allocations it makes
+func measure(f func()) Sample {
+	var before, after runtime.MemStats
+
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+	f()
+	elapsed := time.Since(start)
+	runtime.ReadMemStats(&after)
+
+	return Sample{
+		Elapsed: elapsed,
+		Allocs:  after.Mallocs - before.Mallocs,
+		Bytes:   after.TotalAlloc - before.TotalAlloc,
+	}
+}
+
+// Min returns the fastest run
+func (p *Phase) Min() time.Duration {
+	return p.sorted()[0]
+}
+
+// Median returns the median run time
+func (p *Phase) Median() time.Duration {
+	times := p.sorted()
+	mid := len(times) / 2
+	if len(times)%2 == 0 {
+		return (times[mid-1] + times[mid]) / 2
+	}
+	return times[mid]
+}
+
+// Max returns the slowest run
+func (p *Phase) Max() time.Duration {
+	times := p.sorted()
+	return times[len(times)-1]
+}
+
+// AllocsPerRun returns the mean number of heap allocations per run
+func (p *Phase) AllocsPerRun() uint64 {
+	var sum uint64
+	for _, s := range p.Samples {
+		sum += s.Allocs
+	}
+	return sum / uint64(len(p.Samples))
+}
+
+// BytesPerRun returns the mean number of bytes allocated per run
+func (p *Phase) BytesPerRun() uint64 {
+	var sum uint64
+	for _, s := range p.Samples {
+		sum += s.Bytes
+	}
+	return sum / uint64(len(p.Samples))
+}
+
+func (p *Phase) sorted() []time.Duration {
+	times := make([]time.Duration, len(p.Samples))
+	for i, s := range p.Samples {
+		times[i] = s.Elapsed
+	}
+	sort.Slice(times, func(i, j int) bool {
+		return times[i] < times[j]
+	})
+
+	return times
+}
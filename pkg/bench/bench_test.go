@@ -0,0 +1,140 @@
+package bench
+
+import (
+	"github.com/ryderlewis/aoc2021/pkg/challenge"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func phase(samples ...Sample) *Phase {
+	return &Phase{Name: PhaseTotal, Samples: samples}
+}
+
+func ms(n int) Sample {
+	return Sample{Elapsed: time.Duration(n) * time.Millisecond}
+}
+
+func TestPhaseTimes(t *testing.T) {
+	tests := []struct {
+		phase            *Phase
+		min, median, max time.Duration
+	}{
+		{phase(ms(5)), 5 * time.Millisecond, 5 * time.Millisecond, 5 * time.Millisecond},
+		{phase(ms(9), ms(1), ms(4)), time.Millisecond, 4 * time.Millisecond, 9 * time.Millisecond},
+		{phase(ms(8), ms(2), ms(4), ms(1)), time.Millisecond, 3 * time.Millisecond, 8 * time.Millisecond},
+		{phase(ms(3), ms(3), ms(6), ms(2), ms(3)), 2 * time.Millisecond, 3 * time.Millisecond, 6 * time.Millisecond},
+	}
+	for _, test := range tests {
+		p := test.phase
+		if p.Min() != test.min || p.Median() != test.median || p.Max() != test.max {
+			t.Errorf("%d samples: got min %s, median %s, max %s, want %s, %s, %s",
+				len(p.Samples), p.Min(), p.Median(), p.Max(), test.min, test.median, test.max)
+		}
+	}
+}
+
+func TestPhaseAllocs(t *testing.T) {
+	tests := []struct {
+		samples       []Sample
+		allocs, bytes uint64
+	}{
+		{[]Sample{{Allocs: 7, Bytes: 300}}, 7, 300},
+		{[]Sample{{Allocs: 4, Bytes: 100}, {Allocs: 6, Bytes: 300}}, 5, 200},
+		{[]Sample{{Allocs: 1, Bytes: 10}, {Allocs: 2, Bytes: 10}}, 1, 10}, // means round down
+	}
+	for _, test := range tests {
+		p := phase(test.samples...)
+		if p.AllocsPerRun() != test.allocs || p.BytesPerRun() != test.bytes {
+			t.Errorf("%+v: got %d allocs, %d bytes per run, want %d, %d", test.samples, p.AllocsPerRun(), p.BytesPerRun(), test.allocs, test.bytes)
+		}
+	}
+}
+
+// sink keeps allocations from being optimized away
+var sink [][]byte
+
+// allocator allocates a kilobyte for each line of its input when solving
+type allocator struct {
+	lines int
+}
+
+func (a *allocator) Challenge1(input io.Reader) (string, error) {
+	if err := a.Load(input); err != nil {
+		return "", err
+	}
+	return a.Solve1()
+}
+
+func (a *allocator) Challenge2(input io.Reader) (string, error) {
+	return a.Challenge1(input)
+}
+
+func (a *allocator) Load(input io.Reader) error {
+	b, err := io.ReadAll(input)
+	a.lines = strings.Count(string(b), "\n")
+	return err
+}
+
+func (a *allocator) Solve1() (string, error) {
+	sink = make([][]byte, a.lines)
+	for i := range sink {
+		sink[i] = make([]byte, 1024)
+	}
+	return "done", nil
+}
+
+func (a *allocator) Solve2() (string, error) {
+	return a.Solve1()
+}
+
+// whole hides the phases of an allocator
+type whole struct {
+	a *allocator
+}
+
+func (w whole) Challenge1(input io.Reader) (string, error) { return w.a.Challenge1(input) }
+func (w whole) Challenge2(input io.Reader) (string, error) { return w.a.Challenge2(input) }
+
+func TestRunAllocs(t *testing.T) {
+	input := []byte(strings.Repeat("x\n", 100))
+
+	factories := map[string]challenge.Factory{
+		"phased": func() challenge.DailyChallenge { return &allocator{} },
+		"whole":  func() challenge.DailyChallenge { return whole{&allocator{}} },
+	}
+	for name, factory := range factories {
+		report, err := Run(factory, 1, 1, "a01.txt", input, 3)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+
+		total := report.Phases[len(report.Phases)-1]
+		if total.Name != PhaseTotal || len(total.Samples) != 3 {
+			t.Fatalf("%s: got %+v, want 3 total samples last", name, total)
+		}
+		if total.AllocsPerRun() < 100 || total.BytesPerRun() < 100*1024 {
+			t.Errorf("%s: got %d allocs, %d bytes per run, want at least 100 allocs of 1KiB", name, total.AllocsPerRun(), total.BytesPerRun())
+		}
+
+		if name == "whole" {
+			if len(report.Phases) != 1 {
+				t.Errorf("%s: got %d phases, want only the total", name, len(report.Phases))
+			}
+			continue
+		}
+		if len(report.Phases) != 3 || report.Phases[0].Name != PhaseParse || report.Phases[1].Name != PhaseSolve {
+			t.Fatalf("%s: got phases %+v", name, report.Phases)
+		}
+		for i, s := range total.Samples {
+			parse, solve := report.Phases[0].Samples[i], report.Phases[1].Samples[i]
+			if s.Elapsed != parse.Elapsed+solve.Elapsed || s.Allocs != parse.Allocs+solve.Allocs || s.Bytes != parse.Bytes+solve.Bytes {
+				t.Errorf("%s: run %d total %+v isn't parse %+v plus solve %+v", name, i+1, s, parse, solve)
+			}
+		}
+		if solve := report.Phases[1]; solve.AllocsPerRun() < 100 {
+			t.Errorf("%s: got %d allocs per solve, want the allocations counted there", name, solve.AllocsPerRun())
+		}
+	}
+}
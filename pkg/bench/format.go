@@ -0,0 +1,47 @@
+package bench
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+)
+
+// WriteText writes a table summarizing each report to w
+func WriteText(w io.Writer, reports []*Report) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "DAY\tPART\tINPUT\tPHASE\tRUNS\tMIN\tMEDIAN\tMAX\tALLOCS/RUN\tBYTES/RUN\tANSWER")
+
+	for _, r := range reports {
+		for _, p := range r.Phases {
+			fmt.Fprintf(tw, "%d\t%d\t%s\t%s\t%d\t%s\t%s\t%s\t%d\t%d\t%s\n",
+				r.Day, r.Part, r.Input, p.Name, len(p.Samples),
+				round(p.Min()), round(p.Median()), round(p.Max()),
+				p.AllocsPerRun(), p.BytesPerRun(), r.Answer)
+		}
+	}
+
+	return tw.Flush()
+}
+
+// WriteBenchfmt writes every sample in the Go benchmark format, one line per run and
+// phase, so that results from two separate runs can be compared with benchstat.
+func WriteBenchfmt(w io.Writer, reports []*Report) error {
+	for _, r := range reports {
+		for _, p := range r.Phases {
+			name := fmt.Sprintf("BenchmarkDay%02d/part=%d/input=%s/phase=%s", r.Day, r.Part, r.Input, p.Name)
+			for _, s := range p.Samples {
+				if _, err := fmt.Fprintf(w, "%s\t1\t%d ns/op\t%d B/op\t%d allocs/op\n",
+					name, s.Elapsed.Nanoseconds(), s.Bytes, s.Allocs); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func round(d time.Duration) time.Duration {
+	return d.Round(time.Microsecond)
+}
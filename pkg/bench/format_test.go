@@ -0,0 +1,61 @@
+package bench
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+// benchLine is a line of the Go benchmark format, as benchstat reads it
+var benchLine = regexp.MustCompile(`^Benchmark\S+\t\d+\t\d+ ns/op\t\d+ B/op\t\d+ allocs/op$`)
+
+func TestWriteBenchfmt(t *testing.T) {
+	reports := []*Report{
+		{Day: 6, Part: 2, Input: "b06.txt", Answer: "26984457539", Phases: []*Phase{
+			{Name: PhaseParse, Samples: []Sample{{Elapsed: 1500 * time.Nanosecond, Allocs: 3, Bytes: 96}}},
+			{Name: PhaseSolve, Samples: []Sample{{Elapsed: 2 * time.Microsecond, Allocs: 0, Bytes: 0}}},
+			{Name: PhaseTotal, Samples: []Sample{{Elapsed: 3500 * time.Nanosecond, Allocs: 3, Bytes: 96}}},
+		}},
+		{Day: 12, Part: 1, Input: "a12.txt", Answer: "10", Phases: []*Phase{
+			{Name: PhaseTotal, Samples: []Sample{{Elapsed: time.Millisecond, Allocs: 52, Bytes: 6072}, {Elapsed: 2 * time.Millisecond, Allocs: 52, Bytes: 6072}}},
+		}},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteBenchfmt(&buf, reports); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "BenchmarkDay06/part=2/input=b06.txt/phase=parse\t1\t1500 ns/op\t96 B/op\t3 allocs/op\n" +
+		"BenchmarkDay06/part=2/input=b06.txt/phase=solve\t1\t2000 ns/op\t0 B/op\t0 allocs/op\n" +
+		"BenchmarkDay06/part=2/input=b06.txt/phase=total\t1\t3500 ns/op\t96 B/op\t3 allocs/op\n" +
+		"BenchmarkDay12/part=1/input=a12.txt/phase=total\t1\t1000000 ns/op\t6072 B/op\t52 allocs/op\n" +
+		"BenchmarkDay12/part=1/input=a12.txt/phase=total\t1\t2000000 ns/op\t6072 B/op\t52 allocs/op\n"
+	if buf.String() != want {
+		t.Errorf("got\n%s\nwant\n%s", buf.String(), want)
+	}
+
+	for _, line := range strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n") {
+		if !benchLine.MatchString(line) {
+			t.Errorf("%q isn't in the Go benchmark format", line)
+		}
+	}
+}
+
+func TestWriteText(t *testing.T) {
+	reports := []*Report{{Day: 1, Part: 1, Input: "a01.txt", Answer: "7", Phases: []*Phase{
+		{Name: PhaseTotal, Samples: []Sample{ms(1), ms(3)}},
+	}}}
+
+	var buf bytes.Buffer
+	if err := WriteText(&buf, reports); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 || strings.Join(strings.Fields(lines[1]), " ") != "1 1 a01.txt total 2 1ms 2ms 3ms 0 0 7" {
+		t.Errorf("got\n%s", buf.String())
+	}
+}
@@ -1,10 +1,89 @@
 package challenge
 
 import (
+	"context"
+	"fmt"
 	"io"
 )
 
 type DailyChallenge interface {
 	Challenge1(input io.Reader) (string, error)
-    Challenge2(input io.Reader) (string, error)
+	Challenge2(input io.Reader) (string, error)
+}
+
+// ContextChallenge is implemented by challenges that can abandon a long-running solve
+// when their context is done
+type ContextChallenge interface {
+	Challenge1Context(ctx context.Context, input io.Reader) (string, error)
+	Challenge2Context(ctx context.Context, input io.Reader) (string, error)
+}
+
+// Aborted wraps the context error returned when a solve is abandoned. Callers can test
+// for a timeout with errors.Is(err, context.DeadlineExceeded).
+func Aborted(ctx context.Context) error {
+	return fmt.Errorf("solve aborted: %w", ctx.Err())
+}
+
+// WithContext adapts any DailyChallenge to a ContextChallenge. Challenges that already
+// implement ContextChallenge are returned unchanged. Other challenges are solved on a
+// separate goroutine which is abandoned, not stopped, if the context is done first.
+func WithContext(dc DailyChallenge) ContextChallenge {
+	if cc, ok := dc.(ContextChallenge); ok {
+		return cc
+	}
+	return contextAdapter{dc}
+}
+
+type contextAdapter struct {
+	dc DailyChallenge
+}
+
+func (a contextAdapter) Challenge1Context(ctx context.Context, input io.Reader) (string, error) {
+	return await(ctx, func() (string, error) {
+		return a.dc.Challenge1(input)
+	})
+}
+
+func (a contextAdapter) Challenge2Context(ctx context.Context, input io.Reader) (string, error) {
+	return await(ctx, func() (string, error) {
+		return a.dc.Challenge2(input)
+	})
+}
+
+func await(ctx context.Context, solve func() (string, error)) (string, error) {
+	if ctx.Err() != nil {
+		return "", Aborted(ctx)
+	}
+
+	type answer struct {
+		val string
+		err error
+	}
+	done := make(chan answer, 1)
+
+	go func() {
+		val, err := solve()
+		done <- answer{val, err}
+	}()
+
+	select {
+	case a := <-done:
+		return a.val, a.err
+	case <-ctx.Done():
+		return "", Aborted(ctx)
+	}
+}
+
+// Run solves one part of a challenge, giving up when ctx is done
+func Run(ctx context.Context, dc DailyChallenge, part int, input io.Reader) (string, error) {
+	cc := WithContext(dc)
+
+	switch part {
+	case 1:
+		return cc.Challenge1Context(ctx, input)
+	case 2:
+		return cc.Challenge2Context(ctx, input)
+	default:
+		return "", fmt.Errorf("invalid challenge number: %d", part)
+	}
 }
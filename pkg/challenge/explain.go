@@ -0,0 +1,77 @@
+package challenge
+
+import (
+	"context"
+	"fmt"
+	"io"
+)
+
+// Explanation walks through how an answer was derived, a step at a time. Explaining to a
+// nil *Explanation does nothing, so solvers can explain whether or not anyone asked.
+type Explanation struct {
+	Answer string  `json:"answer"`
+	Steps  []*Step `json:"steps"`
+}
+
+// Step is one stage of the reasoning, with any details that back it up
+type Step struct {
+	Text    string   `json:"text"`
+	Details []string `json:"details,omitempty"`
+}
+
+// Explainer is implemented by challenges that can explain how they solve each part
+type Explainer interface {
+	Challenge1Explain(input io.Reader, e *Explanation) (string, error)
+	Challenge2Explain(input io.Reader, e *Explanation) (string, error)
+}
+
+// Step adds a step to the explanation, and returns it so details can be added
+func (e *Explanation) Step(format string, args ...interface{}) *Step {
+	if e == nil {
+		return nil
+	}
+
+	s := &Step{Text: fmt.Sprintf(format, args...)}
+	e.Steps = append(e.Steps, s)
+	return s
+}
+
+// Detail adds a detail to the step
+func (s *Step) Detail(format string, args ...interface{}) {
+	if s == nil {
+		return
+	}
+	s.Details = append(s.Details, fmt.Sprintf(format, args...))
+}
+
+// Explain solves one part of a challenge that can explain itself, giving up when ctx is
+// done
+func Explain(ctx context.Context, dc DailyChallenge, part int, input io.Reader) (*Explanation, error) {
+	ex, ok := dc.(Explainer)
+	if !ok {
+		return nil, fmt.Errorf("can't be explained")
+	}
+
+	e := &Explanation{Steps: make([]*Step, 0)}
+	answer, err := Run(ctx, explained{ex, e}, part, input)
+	if err != nil {
+		return nil, err
+	}
+	e.Answer = answer
+
+	return e, nil
+}
+
+// explained adapts an Explainer explaining to e to a DailyChallenge
+type explained struct {
+	ex Explainer
+	e  *Explanation
+}
+
+func (x explained) Challenge1(input io.Reader) (string, error) {
+	return x.ex.Challenge1Explain(input, x.e)
+}
+
+func (x explained) Challenge2(input io.Reader) (string, error) {
+	return x.ex.Challenge2Explain(input, x.e)
+}
@@ -0,0 +1,14 @@
+package challenge
+
+import (
+	"io"
+)
+
+// PhasedChallenge is implemented by challenges that parse their input separately from
+// solving it, so that the two phases can be measured on their own. Load must be called
+// before either Solve method.
+type PhasedChallenge interface {
+	Load(input io.Reader) error
+	Solve1() (string, error)
+	Solve2() (string, error)
+}
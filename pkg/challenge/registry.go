@@ -0,0 +1,88 @@
+package challenge
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Factory returns a new, independent instance of a challenge
+type Factory func() DailyChallenge
+
+// ErrNotRegistered is returned by Lookup when no challenge exists for a year and day
+var ErrNotRegistered = errors.New("challenge not registered")
+
+type registryKey struct {
+	year, day int
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[registryKey]Factory)
+)
+
+// Register makes a challenge available under the given year and day. It is meant to be
+// called from the init function of each day's package, and panics if the year and day
+// are already taken.
+func Register(year, day int, factory Factory) {
+	if factory == nil {
+		panic(fmt.Sprintf("challenge: nil factory for %d day %d", year, day))
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	key := registryKey{year: year, day: day}
+	if _, exists := registry[key]; exists {
+		panic(fmt.Sprintf("challenge: %d day %d registered twice", year, day))
+	}
+	registry[key] = factory
+}
+
+// Lookup returns a new instance of the challenge registered for year and day
+func Lookup(year, day int) (DailyChallenge, error) {
+	registryMu.RLock()
+	factory, ok := registry[registryKey{year: year, day: day}]
+	registryMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("%w: %d day %d", ErrNotRegistered, year, day)
+	}
+
+	return factory(), nil
+}
+
+// Years returns every year with at least one registered challenge, in ascending order
+func Years() []int {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	seen := make(map[int]bool)
+	years := make([]int, 0)
+	for key := range registry {
+		if !seen[key.year] {
+			seen[key.year] = true
+			years = append(years, key.year)
+		}
+	}
+	sort.Ints(years)
+
+	return years
+}
+
+// Days returns the registered days for a year, in ascending order
+func Days(year int) []int {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	days := make([]int, 0)
+	for key := range registry {
+		if key.year == year {
+			days = append(days, key.day)
+		}
+	}
+	sort.Ints(days)
+
+	return days
+}
@@ -0,0 +1,92 @@
+package challenge
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ArtifactKind describes how an artifact's value should be interpreted
+type ArtifactKind string
+
+const (
+	GridArtifact ArtifactKind = "grid" // rendered text grid, one row per line
+	PathArtifact ArtifactKind = "path" // sequence of positions, one per line
+	StatArtifact ArtifactKind = "stat" // single value describing the solve
+)
+
+// Artifact is something produced while solving a challenge that is worth showing
+// alongside the answer
+type Artifact struct {
+	Kind  ArtifactKind `json:"kind"`
+	Name  string       `json:"name"`
+	Value string       `json:"value"`
+}
+
+// Result is the primary answer to a challenge plus any artifacts produced on the way
+type Result struct {
+	Answer    string     `json:"answer"`
+	Artifacts []Artifact `json:"artifacts,omitempty"`
+}
+
+// AddGrid attaches a rendered grid to the result
+func (r *Result) AddGrid(name string, rows []string) {
+	r.Artifacts = append(r.Artifacts, Artifact{Kind: GridArtifact, Name: name, Value: strings.Join(rows, "\n")})
+}
+
+// AddPath attaches a path, given as a list of positions, to the result
+func (r *Result) AddPath(name string, steps []string) {
+	r.Artifacts = append(r.Artifacts, Artifact{Kind: PathArtifact, Name: name, Value: strings.Join(steps, "\n")})
+}
+
+// AddStat attaches a named statistic to the result
+func (r *Result) AddStat(name string, value interface{}) {
+	r.Artifacts = append(r.Artifacts, Artifact{Kind: StatArtifact, Name: name, Value: fmt.Sprint(value)})
+}
+
+// ResultChallenge is implemented by challenges that produce a Result rather than a
+// bare answer
+type ResultChallenge interface {
+	Challenge1Result(ctx context.Context, input io.Reader) (*Result, error)
+	Challenge2Result(ctx context.Context, input io.Reader) (*Result, error)
+}
+
+// Solve solves one part of a challenge, giving up when ctx is done. Challenges that
+// don't implement ResultChallenge produce a Result with just an answer.
+func Solve(ctx context.Context, dc DailyChallenge, part int, input io.Reader) (*Result, error) {
+	rc, ok := dc.(ResultChallenge)
+	if !ok {
+		answer, err := Run(ctx, dc, part, input)
+		if err != nil {
+			return nil, err
+		}
+		return &Result{Answer: answer}, nil
+	}
+
+	var solve func(ctx context.Context, input io.Reader) (*Result, error)
+	switch part {
+	case 1:
+		solve = rc.Challenge1Result
+	case 2:
+		solve = rc.Challenge2Result
+	default:
+		return nil, fmt.Errorf("invalid challenge number: %d", part)
+	}
+
+	if _, ok := dc.(ContextChallenge); ok {
+		return solve(ctx, input)
+	}
+
+	// as with Run, a challenge that can't be stopped early is abandoned when ctx is done
+	var result *Result
+	_, err := await(ctx, func() (string, error) {
+		var err error
+		result, err = solve(ctx, input)
+		return "", err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,50 @@
+package challenge
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+)
+
+// stuck returns a Result once release is closed, without looking at its context
+type stuck struct {
+	release chan struct{}
+}
+
+func (s stuck) Challenge1(input io.Reader) (string, error) { return "", nil }
+func (s stuck) Challenge2(input io.Reader) (string, error) { return "", nil }
+
+func (s stuck) Challenge1Result(ctx context.Context, input io.Reader) (*Result, error) {
+	<-s.release
+	return &Result{Answer: "1"}, nil
+}
+
+func (s stuck) Challenge2Result(ctx context.Context, input io.Reader) (*Result, error) {
+	return s.Challenge1Result(ctx, input)
+}
+
+func TestSolveAbandons(t *testing.T) {
+	s := stuck{release: make(chan struct{})}
+	defer close(s.release)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := Solve(ctx, s, 1, nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestSolveResult(t *testing.T) {
+	s := stuck{release: make(chan struct{})}
+	close(s.release)
+
+	result, err := Solve(context.Background(), s, 2, nil)
+	if err != nil || result.Answer != "1" {
+		t.Errorf("got %+v, %v", result, err)
+	}
+	if _, err := Solve(context.Background(), s, 3, nil); err == nil {
+		t.Error("got no error for part 3")
+	}
+}
@@ -0,0 +1,206 @@
+// Package fetch downloads puzzle inputs and caches them in the inputs directory. A cached
+// input is only downloaded again if the server says it has changed.
+package fetch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ryderlewis/aoc2021/pkg/inputs"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultBaseURL is the site puzzle inputs are downloaded from
+	DefaultBaseURL = "https://adventofcode.com"
+
+	// DefaultInterval is the least time allowed between requests to the server
+	DefaultInterval = 3 * time.Second
+
+	// DefaultTimeout is how long the default HTTP client waits for each request
+	DefaultTimeout = time.Minute
+
+	// SessionEnv is the environment variable holding the session token
+	SessionEnv = "AOC_SESSION"
+
+	userAgent = "github.com/ryderlewis/aoc2021/pkg/fetch"
+)
+
+var ErrNoSession = errors.New("no session token")
+
+// defaultHTTPClient gives up on a server that stalls, unlike http.DefaultClient
+var defaultHTTPClient = &http.Client{Timeout: DefaultTimeout}
+
+// StatusError is returned when the server responds with anything but the input
+type StatusError struct {
+	URL        string
+	StatusCode int
+	Body       string
+}
+
+func (e *StatusError) Error() string {
+	msg := fmt.Sprintf("fetching %s: %s", e.URL, http.StatusText(e.StatusCode))
+	if e.Body != "" {
+		msg += ": " + e.Body
+	}
+	return msg
+}
+
+// Client downloads puzzle inputs into Dir, using the same names as the inputs package
+type Client struct {
+	BaseURL    string
+	Session    string
+	Dir        string
+	Interval   time.Duration // least time between requests, 0 for no limit
+	HTTPClient *http.Client
+
+	mu   sync.Mutex
+	last time.Time // time of the last request
+}
+
+// New returns a client that downloads from the real site into dir
+func New(session, dir string) *Client {
+	return &Client{
+		BaseURL:    DefaultBaseURL,
+		Session:    session,
+		Dir:        dir,
+		Interval:   DefaultInterval,
+		HTTPClient: defaultHTTPClient,
+	}
+}
+
+// Result describes the outcome of fetching one input
+type Result struct {
+	Path       string
+	Downloaded bool // false if the cached file was already up to date
+}
+
+// Fetch makes sure the real input for a day is in the cache, downloading it if it is
+// missing or has changed. Requests are spaced at least Interval apart.
+func (c *Client) Fetch(ctx context.Context, year, day int) (*Result, error) {
+	if c.Session == "" {
+		return nil, ErrNoSession
+	}
+
+	result := &Result{Path: inputs.Path(c.Dir, year, day, false)}
+	url := fmt.Sprintf("%s/%d/day/%d/input", strings.TrimSuffix(c.BaseURL, "/"), year, day)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", userAgent)
+	req.AddCookie(&http.Cookie{Name: "session", Value: c.Session})
+
+	if info, err := os.Stat(result.Path); err == nil {
+		req.Header.Set("If-Modified-Since", info.ModTime().UTC().Format(http.TimeFormat))
+	}
+
+	if err := c.wait(ctx); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient().Do(req)
+	c.answered()
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		if err := save(result.Path, resp); err != nil {
+			return nil, err
+		}
+		result.Downloaded = true
+		return result, nil
+	case http.StatusNotModified:
+		return result, nil
+	default:
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, &StatusError{URL: url, StatusCode: resp.StatusCode, Body: strings.TrimSpace(string(body))}
+	}
+}
+
+func (c *Client) httpClient() *http.Client {
+	if c.HTTPClient != nil {
+		return c.HTTPClient
+	}
+	return defaultHTTPClient
+}
+
+// wait blocks until Interval has passed since the last request, then claims the slot for
+// the request about to be sent. Giving up on the wait leaves the slot to the next request.
+func (c *Client) wait(ctx context.Context) error {
+	for {
+		c.mu.Lock()
+		next, now := c.last.Add(c.Interval), time.Now()
+		if !next.After(now) {
+			c.last = now
+			c.mu.Unlock()
+			return nil
+		}
+		c.mu.Unlock()
+
+		// another request may claim the slot first, so check again once it comes
+		timer := time.NewTimer(next.Sub(now))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// answered counts the interval from when the server answered, rather than from when the
+// request was sent, so that a slow request doesn't bring the next one closer to it
+func (c *Client) answered() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if now := time.Now(); now.After(c.last) {
+		c.last = now
+	}
+}
+
+// save writes the response body to path, replacing any earlier copy only once the whole
+// body has arrived. The file's modification time is set from Last-Modified so it can be
+// sent back as If-Modified-Since.
+func save(path string, resp *http.Response) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := tmp.Chmod(0644); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := io.Copy(tmp, resp.Body); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if modified, err := http.ParseTime(resp.Header.Get("Last-Modified")); err == nil {
+		if err := os.Chtimes(tmp.Name(), modified, modified); err != nil {
+			return err
+		}
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
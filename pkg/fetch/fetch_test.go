@@ -0,0 +1,222 @@
+package fetch
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+const session = "53616c7465645f5f"
+
+// server stands in for the real site, serving one input that was last modified at
+// modified after taking delay to answer, and counting the requests that had to send the
+// whole input
+type server struct {
+	*httptest.Server
+	modified time.Time
+	delay    time.Duration
+
+	mu        sync.Mutex
+	downloads int
+	requests  []time.Time // when each request came
+	answers   []time.Time // when each request was answered
+}
+
+func newServer(t *testing.T) *server {
+	s := &server{modified: time.Date(2021, 12, 1, 5, 0, 0, 0, time.UTC)}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.requests = append(s.requests, time.Now())
+		time.Sleep(s.delay)
+		defer func() { s.answers = append(s.answers, time.Now()) }()
+
+		if r.URL.Path != "/2021/day/1/input" {
+			http.NotFound(w, r)
+			return
+		}
+		if c, err := r.Cookie("session"); err != nil || c.Value != session {
+			http.Error(w, "Puzzle inputs differ by user.  Please log in to get your puzzle input.", http.StatusBadRequest)
+			return
+		}
+
+		if since, err := http.ParseTime(r.Header.Get("If-Modified-Since")); err == nil && !s.modified.After(since) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		s.downloads++
+		w.Header().Set("Last-Modified", s.modified.Format(http.TimeFormat))
+		_, _ = io.WriteString(w, "199\n200\n208\n")
+	}))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+func newClient(s *server, dir string) *Client {
+	c := New(session, dir)
+	c.BaseURL = s.URL
+	c.HTTPClient = s.Client()
+	c.Interval = 0
+	return c
+}
+
+func TestFetch(t *testing.T) {
+	s := newServer(t)
+	dir := t.TempDir()
+	c := newClient(s, dir)
+
+	result, err := c.Fetch(context.Background(), 2021, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := filepath.Join(dir, "2021", "b01.txt"); result.Path != want {
+		t.Errorf("got path %s, want %s", result.Path, want)
+	}
+	if !result.Downloaded {
+		t.Errorf("first fetch wasn't downloaded")
+	}
+
+	data, err := os.ReadFile(result.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(data), "199\n200\n208\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	// the cached copy is up to date, so fetching again shouldn't download it
+	if result, err = c.Fetch(context.Background(), 2021, 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Downloaded || s.downloads != 1 {
+		t.Errorf("got %d downloads, want 1", s.downloads)
+	}
+
+	// once the input changes on the server it is downloaded again
+	s.modified = s.modified.Add(time.Hour)
+	if result, err = c.Fetch(context.Background(), 2021, 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.Downloaded || s.downloads != 2 {
+		t.Errorf("got %d downloads, want 2", s.downloads)
+	}
+}
+
+func TestFetchErrors(t *testing.T) {
+	s := newServer(t)
+	dir := t.TempDir()
+
+	c := newClient(s, dir)
+	c.Session = ""
+	if _, err := c.Fetch(context.Background(), 2021, 1); !errors.Is(err, ErrNoSession) {
+		t.Errorf("got %v, want ErrNoSession", err)
+	}
+
+	c.Session = "expired"
+	_, err := c.Fetch(context.Background(), 2021, 1)
+	var serr *StatusError
+	if !errors.As(err, &serr) || serr.StatusCode != http.StatusBadRequest {
+		t.Errorf("got %v, want a bad request StatusError", err)
+	}
+
+	c.Session = session
+	_, err = c.Fetch(context.Background(), 2021, 26)
+	if !errors.As(err, &serr) || serr.StatusCode != http.StatusNotFound {
+		t.Errorf("got %v, want a not found StatusError", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "2021", "b01.txt")); !os.IsNotExist(err) {
+		t.Errorf("failed fetches left a cached input: %v", err)
+	}
+}
+
+func TestFetchRateLimit(t *testing.T) {
+	s := newServer(t)
+	c := newClient(s, t.TempDir())
+	c.Interval = 50 * time.Millisecond
+
+	for i := 0; i < 3; i++ {
+		if _, err := c.Fetch(context.Background(), 2021, 1); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	for i := 1; i < len(s.requests); i++ {
+		if gap := s.requests[i].Sub(s.answers[i-1]); gap < c.Interval {
+			t.Errorf("request %d came %s after the one before was answered, want at least %s", i+1, gap, c.Interval)
+		}
+	}
+
+	// a cancelled context gives up waiting for the next slot
+	c.Interval = time.Hour
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := c.Fetch(ctx, 2021, 1); !errors.Is(err, context.Canceled) {
+		t.Errorf("got %v, want context.Canceled", err)
+	}
+}
+
+func TestFetchSlowServer(t *testing.T) {
+	s := newServer(t)
+	s.delay = 80 * time.Millisecond
+	c := newClient(s, t.TempDir())
+	c.Interval = 50 * time.Millisecond
+
+	for i := 0; i < 3; i++ {
+		if _, err := c.Fetch(context.Background(), 2021, 1); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	// the interval counts from the end of a slow response, not from when it was sent
+	for i := 1; i < len(s.requests); i++ {
+		if gap := s.requests[i].Sub(s.answers[i-1]); gap < c.Interval {
+			t.Errorf("request %d came %s after the one before was answered, want at least %s", i+1, gap, c.Interval)
+		}
+	}
+}
+
+func TestFetchCancelledWait(t *testing.T) {
+	s := newServer(t)
+	c := newClient(s, t.TempDir())
+	c.Interval = 100 * time.Millisecond
+
+	if _, err := c.Fetch(context.Background(), 2021, 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := c.Fetch(ctx, 2021, 1); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v, want context.DeadlineExceeded", err)
+	}
+	if _, err := c.Fetch(context.Background(), 2021, 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// the request that gave up sent nothing, so it didn't hold back the one after it
+	if len(s.requests) != 2 {
+		t.Fatalf("got %d requests, want 2", len(s.requests))
+	}
+	if gap := s.requests[1].Sub(s.answers[0]); gap < c.Interval || gap >= 2*c.Interval {
+		t.Errorf("request 2 came %s after the first was answered, want between %s and %s", gap, c.Interval, 2*c.Interval)
+	}
+}
+
+func TestDefaultTimeout(t *testing.T) {
+	if c := New(session, t.TempDir()); c.HTTPClient.Timeout != DefaultTimeout || c.httpClient().Timeout == 0 {
+		t.Errorf("got timeout %s, want %s", c.HTTPClient.Timeout, DefaultTimeout)
+	}
+	if c := (&Client{}); c.httpClient().Timeout != DefaultTimeout {
+		t.Errorf("got timeout %s for a client without an HTTP client, want %s", c.httpClient().Timeout, DefaultTimeout)
+	}
+}
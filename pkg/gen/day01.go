@@ -0,0 +1,13 @@
+package gen
+
+// day01 writes a sonar sweep, a random walk of depths that never goes above the surface
+func day01(b *builder) {
+	depth := b.between(100, 200)
+	for i := 0; i < b.scale; i++ {
+		b.printf("%d\n", depth)
+		depth += b.between(-10, 20)
+		if depth < 0 {
+			depth = -depth
+		}
+	}
+}
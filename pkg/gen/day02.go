@@ -0,0 +1,27 @@
+package gen
+
+// day02 writes submarine commands that never take it above the surface
+func day02(b *builder) {
+	depth := 0
+	for i := 0; i < b.scale; i++ {
+		n := b.between(1, 9)
+		switch b.rng.Intn(3) {
+		case 0:
+			b.printf("forward %d\n", n)
+		case 1:
+			b.printf("down %d\n", n)
+			depth += n
+		default:
+			if n > depth {
+				n = depth
+			}
+			if n == 0 {
+				b.printf("down %d\n", 1)
+				depth++
+				continue
+			}
+			b.printf("up %d\n", n)
+			depth -= n
+		}
+	}
+}
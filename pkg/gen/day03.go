@@ -0,0 +1,20 @@
+package gen
+
+// day03 writes distinct binary numbers, all the same width. The width grows with the
+// scale so there are always enough distinct values.
+func day03(b *builder) {
+	width := 5
+	for 1<<width < 4*b.scale {
+		width++
+	}
+
+	seen := make(map[int]bool)
+	for len(seen) < b.scale {
+		n := b.rng.Intn(1 << width)
+		if seen[n] {
+			continue
+		}
+		seen[n] = true
+		b.printf("%0*b\n", width, n)
+	}
+}
@@ -0,0 +1,28 @@
+package gen
+
+// day04 writes every number from 0 to 99 in a random order, followed by bingo boards.
+// Since every number is drawn, every board wins eventually.
+func day04(b *builder) {
+	draws := b.rng.Perm(100)
+	for i, n := range draws {
+		if i > 0 {
+			b.printf(",")
+		}
+		b.printf("%d", n)
+	}
+	b.printf("\n")
+
+	for i := 0; i < b.scale; i++ {
+		b.printf("\n")
+		nums := b.rng.Perm(100)
+		for row := 0; row < 5; row++ {
+			for col := 0; col < 5; col++ {
+				if col > 0 {
+					b.printf(" ")
+				}
+				b.printf("%2d", nums[row*5+col])
+			}
+			b.printf("\n")
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package gen
+
+// day05 writes horizontal, vertical and diagonal vent lines on a 1000 by 1000 floor
+func day05(b *builder) {
+	for i := 0; i < b.scale; i++ {
+		x1, y1 := b.rng.Intn(1000), b.rng.Intn(1000)
+		x2, y2 := x1, y1
+		for x2 == x1 && y2 == y1 {
+			switch b.rng.Intn(3) {
+			case 0:
+				x2 = b.rng.Intn(1000)
+			case 1:
+				y2 = b.rng.Intn(1000)
+			default:
+				// a diagonal at 45 degrees, kept on the floor
+				d := b.between(1, 999)
+				dx, dy := d, d
+				if b.rng.Intn(2) == 0 {
+					dx = -dx
+				}
+				if b.rng.Intn(2) == 0 {
+					dy = -dy
+				}
+				if x1+dx < 0 || x1+dx > 999 || y1+dy < 0 || y1+dy > 999 {
+					continue
+				}
+				x2, y2 = x1+dx, y1+dy
+			}
+		}
+		b.printf("%d,%d -> %d,%d\n", x1, y1, x2, y2)
+	}
+}
@@ -0,0 +1,12 @@
+package gen
+
+// day06 writes the timers of a school of lanternfish
+func day06(b *builder) {
+	for i := 0; i < b.scale; i++ {
+		if i > 0 {
+			b.printf(",")
+		}
+		b.printf("%d", b.between(1, 5))
+	}
+	b.printf("\n")
+}
@@ -0,0 +1,12 @@
+package gen
+
+// day07 writes the horizontal positions of crabs, bunched towards the low end
+func day07(b *builder) {
+	for i := 0; i < b.scale; i++ {
+		if i > 0 {
+			b.printf(",")
+		}
+		b.printf("%d", b.rng.Intn(1+b.rng.Intn(2000)))
+	}
+	b.printf("\n")
+}
@@ -0,0 +1,32 @@
+package gen
+
+// segments are the segments lit for each digit, before the wires are crossed
+var segments = []string{"abcefg", "cf", "acdeg", "acdfg", "bcdf", "abdfg", "abdefg", "acf", "abcdefg", "abcdfg"}
+
+// day08 writes seven segment displays with randomly crossed wires. Each shows the ten
+// unique patterns in a random order, then four output digits.
+func day08(b *builder) {
+	for i := 0; i < b.scale; i++ {
+		wires := b.rng.Perm(7)
+		pattern := func(digit int) string {
+			lit := []byte(segments[digit])
+			b.rng.Shuffle(len(lit), func(i, j int) { lit[i], lit[j] = lit[j], lit[i] })
+			for j, c := range lit {
+				lit[j] = byte('a' + wires[c-'a'])
+			}
+			return string(lit)
+		}
+
+		for j, digit := range b.rng.Perm(10) {
+			if j > 0 {
+				b.printf(" ")
+			}
+			b.printf("%s", pattern(digit))
+		}
+		b.printf(" |")
+		for j := 0; j < 4; j++ {
+			b.printf(" %s", pattern(b.rng.Intn(10)))
+		}
+		b.printf("\n")
+	}
+}
@@ -0,0 +1,6 @@
+package gen
+
+// day09 writes a heightmap, with extra 9s to wall off basins
+func day09(b *builder) {
+	b.grid(b.scale, "0123456789999")
+}
@@ -0,0 +1,63 @@
+package gen
+
+const (
+	openers = "([{<"
+	closers = ")]}>"
+)
+
+// day10 writes navigation lines that are either corrupted or incomplete. There is always
+// an odd number of incomplete lines, so that they have a middle score.
+func day10(b *builder) {
+	incomplete := 0
+	for i := 0; i < b.scale; i++ {
+		corrupt := b.rng.Intn(2) == 0
+		if i == b.scale-1 {
+			corrupt = incomplete%2 == 1
+		}
+		if !corrupt {
+			incomplete++
+		}
+
+		line := make([]byte, 0, 110)
+		stack := make([]int, 0, 110)
+		for n := b.between(80, 110); len(line) < n || len(stack) == 0; {
+			if len(stack) == 0 || b.rng.Intn(5) < 3 {
+				c := b.rng.Intn(len(openers))
+				line = append(line, openers[c])
+				stack = append(stack, c)
+			} else {
+				line = append(line, closers[stack[len(stack)-1]])
+				stack = stack[:len(stack)-1]
+			}
+		}
+
+		if corrupt {
+			// close the wrong chunk somewhere in the middle of the line
+			pos := b.rng.Intn(len(line))
+			for pos > 0 && !isOpener(line[pos-1]) {
+				pos--
+			}
+			if pos == 0 {
+				pos = 1
+			}
+			want := indexByte(openers, line[pos-1])
+			wrong := (want + b.between(1, 3)) % len(closers)
+			line = append(line[:pos], closers[wrong])
+		}
+
+		b.printf("%s\n", line)
+	}
+}
+
+func isOpener(c byte) bool {
+	return indexByte(openers, c) >= 0
+}
+
+func indexByte(s string, c byte) int {
+	for i := 0; i < len(s); i++ {
+		if s[i] == c {
+			return i
+		}
+	}
+	return -1
+}
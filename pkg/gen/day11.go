@@ -0,0 +1,7 @@
+package gen
+
+// day11 writes the energy levels of a grid of octopuses. The puzzle's grid is always 10
+// by 10, and the second part only ends once all 100 octopuses flash together.
+func day11(b *builder) {
+	b.grid(b.scale, "0123456789")
+}
@@ -0,0 +1,51 @@
+package gen
+
+// day12 writes a connected cave system. Big caves are never connected to each other,
+// otherwise there would be infinitely many paths.
+func day12(b *builder) {
+	names := []string{"start", "end"}
+	seen := map[string]bool{"start": true, "end": true}
+	for len(names) < b.scale {
+		letters := "abcdefghijklmnopqrstuvwxyz"
+		if len(names)%3 == 2 {
+			letters = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+		}
+		name := string([]byte{b.pick(letters), b.pick(letters)})
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+
+	big := func(name string) bool {
+		return name[0] >= 'A' && name[0] <= 'Z'
+	}
+
+	type edge struct{ a, b string }
+	edges := make([]edge, 0)
+	connected := make(map[edge]bool)
+	connect := func(x, y string) bool {
+		if x == y || (big(x) && big(y)) || connected[edge{x, y}] {
+			return false
+		}
+		connected[edge{x, y}], connected[edge{y, x}] = true, true
+		edges = append(edges, edge{x, y})
+		return true
+	}
+
+	// join every cave to one already in the system, then add a few more connections
+	order := append([]string{"start"}, names[1:]...)
+	b.rng.Shuffle(len(order)-1, func(i, j int) { order[i+1], order[j+1] = order[j+1], order[i+1] })
+	for i := 1; i < len(order); i++ {
+		for !connect(order[i], order[b.rng.Intn(i)]) {
+		}
+	}
+	for tries := 0; tries < len(names); tries++ {
+		connect(names[b.rng.Intn(len(names))], names[b.rng.Intn(len(names))])
+	}
+
+	b.rng.Shuffle(len(edges), func(i, j int) { edges[i], edges[j] = edges[j], edges[i] })
+	for _, e := range edges {
+		b.printf("%s-%s\n", e.a, e.b)
+	}
+}
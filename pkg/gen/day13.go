@@ -0,0 +1,64 @@
+package gen
+
+// day13 writes dots on transparent paper and the folds that bring them down to the
+// puzzle's 40 by 6 code. Each dot is placed in the final code, then unfolded to a random
+// side of every fold, so no dot ever lands on a fold line.
+func day13(b *builder) {
+	xFolds := unfolds(40, 5)
+	yFolds := unfolds(6, 7)
+
+	// there is only so much room on the paper
+	n := b.scale
+	if max := 40 * 6 << (len(xFolds) + len(yFolds)); n > max {
+		n = max
+	}
+
+	type dot struct{ x, y int }
+	dots := make([]dot, 0, n)
+	seen := make(map[dot]bool)
+	for len(dots) < n {
+		d := dot{b.rng.Intn(40), b.rng.Intn(6)}
+		for _, f := range xFolds {
+			if b.rng.Intn(2) == 0 {
+				d.x = 2*f - d.x
+			}
+		}
+		for _, f := range yFolds {
+			if b.rng.Intn(2) == 0 {
+				d.y = 2*f - d.y
+			}
+		}
+		if !seen[d] {
+			seen[d] = true
+			dots = append(dots, d)
+		}
+	}
+
+	for _, d := range dots {
+		b.printf("%d,%d\n", d.x, d.y)
+	}
+	b.printf("\n")
+
+	// fold the largest first, interleaving the two directions
+	for x, y := len(xFolds)-1, len(yFolds)-1; x >= 0 || y >= 0; {
+		if y < 0 || (x >= 0 && b.rng.Intn(2) == 0) {
+			b.printf("fold along x=%d\n", xFolds[x])
+			x--
+		} else {
+			b.printf("fold along y=%d\n", yFolds[y])
+			y--
+		}
+	}
+}
+
+// unfolds returns the fold lines that take a size wide paper to its unfolded size,
+// smallest first
+func unfolds(size, n int) []int {
+	folds := make([]int, n)
+	for i := range folds {
+		folds[i] = size
+		size = 2*size + 1
+	}
+
+	return folds
+}
@@ -0,0 +1,20 @@
+package gen
+
+// day14 writes a polymer template and an insertion rule for every pair of its elements
+func day14(b *builder) {
+	elements := []byte("ABCDEFGHIJKLMNOPQRSTUVWXYZ")
+	b.rng.Shuffle(len(elements), func(i, j int) { elements[i], elements[j] = elements[j], elements[i] })
+	elements = elements[:10]
+
+	template := make([]byte, b.scale)
+	for i := range template {
+		template[i] = elements[b.rng.Intn(len(elements))]
+	}
+	b.printf("%s\n\n", template)
+
+	for _, first := range elements {
+		for _, second := range elements {
+			b.printf("%c%c -> %c\n", first, second, elements[b.rng.Intn(len(elements))])
+		}
+	}
+}
@@ -0,0 +1,6 @@
+package gen
+
+// day15 writes the risk levels of a square cavern
+func day15(b *builder) {
+	b.grid(b.scale, "123456789")
+}
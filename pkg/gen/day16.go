@@ -0,0 +1,181 @@
+package gen
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// packet types, as in the BITS specification
+const (
+	sumType = iota
+	productType
+	minimumType
+	maximumType
+	literalType
+	greaterType
+	lessType
+	equalType
+)
+
+// packet is a generated BITS packet
+type packet struct {
+	version int
+	typeID  int
+	value   int
+	subs    []*packet
+}
+
+// day16 writes a BITS transmission holding a random expression of about scale packets.
+// Both answers are known, from the generated packets.
+func day16(b *builder) {
+	p := b.packet(b.scale)
+
+	var bits strings.Builder
+	b.encode(&bits, p)
+	for bits.Len()%4 != 0 {
+		bits.WriteByte('0')
+	}
+
+	s := bits.String()
+	for i := 0; i < len(s); i += 4 {
+		nibble, _ := strconv.ParseUint(s[i:i+4], 2, 4)
+		b.printf("%X", nibble)
+	}
+	b.printf("\n")
+
+	b.answer(1, p.versions())
+	b.answer(2, p.eval())
+}
+
+// packet returns a packet holding n packets, counting itself
+func (b *builder) packet(n int) *packet {
+	p := &packet{version: b.rng.Intn(8)}
+
+	switch {
+	case n <= 1:
+		p.typeID = literalType
+		p.value = b.rng.Intn(1 << (4 * b.between(1, 3)))
+		return p
+	case n == 2:
+		p.typeID = b.rng.Intn(4)
+	default:
+		p.typeID = []int{sumType, productType, minimumType, maximumType, greaterType, lessType, equalType}[b.rng.Intn(7)]
+	}
+
+	// share the remaining packets out between the sub-packets
+	count := 2
+	if p.typeID < literalType {
+		count = b.between(1, 4)
+	}
+	if count > n-1 {
+		count = n - 1
+	}
+	sizes := make([]int, count)
+	for i := range sizes {
+		sizes[i] = 1
+	}
+	for i := count; i < n-1; i++ {
+		sizes[b.rng.Intn(count)]++
+	}
+
+	for _, size := range sizes {
+		p.subs = append(p.subs, b.packet(size))
+	}
+
+	return p
+}
+
+// encode writes the packet as a string of bits
+func (b *builder) encode(bits *strings.Builder, p *packet) {
+	fmt.Fprintf(bits, "%03b%03b", p.version, p.typeID)
+
+	if p.typeID == literalType {
+		groups := make([]int, 0)
+		for v := p.value; v > 0 || len(groups) == 0; v >>= 4 {
+			groups = append(groups, v&0xf)
+		}
+		for i := len(groups) - 1; i >= 0; i-- {
+			more := 0
+			if i > 0 {
+				more = 1
+			}
+			fmt.Fprintf(bits, "%b%04b", more, groups[i])
+		}
+		return
+	}
+
+	var subs strings.Builder
+	for _, sub := range p.subs {
+		b.encode(&subs, sub)
+	}
+
+	if subs.Len() < 1<<15 && b.rng.Intn(2) == 0 {
+		fmt.Fprintf(bits, "0%015b", subs.Len())
+	} else {
+		fmt.Fprintf(bits, "1%011b", len(p.subs))
+	}
+	bits.WriteString(subs.String())
+}
+
+func (p *packet) versions() int {
+	sum := p.version
+	for _, sub := range p.subs {
+		sum += sub.versions()
+	}
+
+	return sum
+}
+
+func (p *packet) eval() int {
+	if p.typeID == literalType {
+		return p.value
+	}
+
+	vals := make([]int, len(p.subs))
+	for i, sub := range p.subs {
+		vals[i] = sub.eval()
+	}
+
+	val := 0
+	switch p.typeID {
+	case sumType:
+		for _, v := range vals {
+			val += v
+		}
+	case productType:
+		val = 1
+		for _, v := range vals {
+			val *= v
+		}
+	case minimumType:
+		val = math.MaxInt
+		for _, v := range vals {
+			if v < val {
+				val = v
+			}
+		}
+	case maximumType:
+		val = math.MinInt
+		for _, v := range vals {
+			if v > val {
+				val = v
+			}
+		}
+	case greaterType:
+		if vals[0] > vals[1] {
+			val = 1
+		}
+	case lessType:
+		if vals[0] < vals[1] {
+			val = 1
+		}
+	case equalType:
+		if vals[0] == vals[1] {
+			val = 1
+		}
+	}
+
+	return val
+}
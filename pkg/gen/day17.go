@@ -0,0 +1,13 @@
+package gen
+
+// day17 writes a target area ahead of and below the probe. The scale is roughly how far
+// away the target is in each direction.
+func day17(b *builder) {
+	near := b.between(b.scale/2+1, b.scale)
+	far := near + b.rng.Intn(near/3+1)
+
+	bottom := -b.between(b.scale/2+1, b.scale)
+	top := bottom + b.rng.Intn((1-bottom)/2)
+
+	b.printf("target area: x=%d..%d, y=%d..%d\n", near, far, bottom, top)
+}
@@ -0,0 +1,25 @@
+package gen
+
+// day18 writes reduced snailfish numbers, with pairs nested up to four deep and regular
+// numbers up to 9
+func day18(b *builder) {
+	for i := 0; i < b.scale; i++ {
+		b.snailfish(0)
+		b.printf("\n")
+	}
+}
+
+func (b *builder) snailfish(depth int) {
+	b.printf("[")
+	for i := 0; i < 2; i++ {
+		if i > 0 {
+			b.printf(",")
+		}
+		if depth < 3 && b.rng.Intn(3) > 0 {
+			b.snailfish(depth + 1)
+		} else {
+			b.printf("%d", b.rng.Intn(10))
+		}
+	}
+	b.printf("]")
+}
@@ -0,0 +1,128 @@
+package gen
+
+// scanRange is how far a scanner can see along each axis
+const scanRange = 1000
+
+type point [3]int
+
+func (p point) sub(q point) point {
+	return point{p[0] - q[0], p[1] - q[1], p[2] - q[2]}
+}
+
+// sees reports whether a scanner at s detects a beacon at p
+func (s point) sees(p point) bool {
+	d := p.sub(s)
+	return abs(d[0]) <= scanRange && abs(d[1]) <= scanRange && abs(d[2]) <= scanRange
+}
+
+// rotation maps each axis of the world to a signed axis of a scanner
+type rotation struct {
+	axis [3]int
+	sign [3]int
+}
+
+func (r rotation) apply(p point) point {
+	return point{r.sign[0] * p[r.axis[0]], r.sign[1] * p[r.axis[1]], r.sign[2] * p[r.axis[2]]}
+}
+
+// rotations are the 24 ways a scanner can face
+var rotations = func() []rotation {
+	rs := make([]rotation, 0, 24)
+	// the last three permutations of the axes are odd, and need an odd number of
+	// negated axes to stay a rotation rather than a reflection
+	for k, axis := range [][3]int{{0, 1, 2}, {1, 2, 0}, {2, 0, 1}, {0, 2, 1}, {2, 1, 0}, {1, 0, 2}} {
+		odd := k >= 3
+		for signs := 0; signs < 8; signs++ {
+			r := rotation{axis: axis}
+			negative := false
+			for i := range r.sign {
+				r.sign[i] = 1
+				if signs&(1<<i) != 0 {
+					r.sign[i] = -1
+					negative = !negative
+				}
+			}
+			if negative == odd {
+				rs = append(rs, r)
+			}
+		}
+	}
+	return rs
+}()
+
+// day19 writes the reports of scanners scattered around the trench. Every scanner after
+// the first is placed so that it shares at least 12 beacons with an earlier one, so the
+// whole map can be assembled. Both answers are known, from the generated positions.
+func day19(b *builder) {
+	scanners := []point{{0, 0, 0}}
+	beacons := make([]point, 0)
+	seen := make(map[point]bool)
+	addBeacon := func(p point) {
+		if !seen[p] {
+			seen[p] = true
+			beacons = append(beacons, p)
+		}
+	}
+
+	// scatter beacons around s, count of them also visible from from
+	scatter := func(s, from point, count int) {
+		for n := 0; n < count; {
+			p := point{}
+			for i := range p {
+				p[i] = s[i] + b.between(-scanRange, scanRange)
+			}
+			if from.sees(p) && !seen[p] {
+				addBeacon(p)
+				n++
+			}
+		}
+	}
+
+	scatter(scanners[0], scanners[0], 25)
+	for len(scanners) < b.scale {
+		parent := scanners[b.rng.Intn(len(scanners))]
+		s := parent
+		for i := range s {
+			offset := b.between(400, 1100)
+			if b.rng.Intn(2) == 0 {
+				offset = -offset
+			}
+			s[i] += offset
+		}
+
+		scatter(s, parent, 12)
+		scatter(s, s, 13)
+		scanners = append(scanners, s)
+	}
+
+	for i, s := range scanners {
+		if i > 0 {
+			b.printf("\n")
+		}
+		b.printf("--- scanner %d ---\n", i)
+
+		r := rotations[0]
+		if i > 0 {
+			r = rotations[b.rng.Intn(len(rotations))]
+		}
+		for _, j := range b.rng.Perm(len(beacons)) {
+			if s.sees(beacons[j]) {
+				p := r.apply(beacons[j].sub(s))
+				b.printf("%d,%d,%d\n", p[0], p[1], p[2])
+			}
+		}
+	}
+
+	b.answer(1, len(beacons))
+
+	furthest := 0
+	for _, s1 := range scanners {
+		for _, s2 := range scanners {
+			d := s1.sub(s2)
+			if m := abs(d[0]) + abs(d[1]) + abs(d[2]); m > furthest {
+				furthest = m
+			}
+		}
+	}
+	b.answer(2, furthest)
+}
@@ -0,0 +1,17 @@
+package gen
+
+// day20 writes an image enhancement algorithm and an image. If the algorithm lights
+// pixels surrounded by darkness, it darkens pixels surrounded by light, so the lit count
+// stays finite after every other step.
+func day20(b *builder) {
+	algorithm := make([]byte, 512)
+	for i := range algorithm {
+		algorithm[i] = b.pick(".#")
+	}
+	if algorithm[0] == '#' {
+		algorithm[511] = '.'
+	}
+	b.printf("%s\n\n", algorithm)
+
+	b.grid(b.scale, ".#")
+}
@@ -0,0 +1,8 @@
+package gen
+
+// day21 writes the starting positions of the two players. The board always has 10
+// spaces, so the scale is ignored.
+func day21(b *builder) {
+	b.printf("Player 1 starting position: %d\n", b.between(1, 10))
+	b.printf("Player 2 starting position: %d\n", b.between(1, 10))
+}
@@ -0,0 +1,31 @@
+package gen
+
+// day22 writes reboot steps. Like the puzzle, the first steps are all within the
+// initialization region, from -50 to 50 on each axis, and the rest reach far outside it.
+func day22(b *builder) {
+	for i := 0; i < b.scale; i++ {
+		state := "on"
+		if i > 0 && b.rng.Intn(4) == 0 {
+			state = "off"
+		}
+
+		reach, size := 50, 50
+		if i >= 20 {
+			reach, size = 100000, 40000
+		}
+
+		b.printf("%s ", state)
+		for j, axis := range "xyz" {
+			if j > 0 {
+				b.printf(",")
+			}
+			lo := b.between(-reach, reach-1)
+			hi := lo + b.between(0, size)
+			if hi > reach {
+				hi = reach
+			}
+			b.printf("%c=%d..%d", axis, lo, hi)
+		}
+		b.printf("\n")
+	}
+}
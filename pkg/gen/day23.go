@@ -0,0 +1,14 @@
+package gen
+
+// day23 writes a burrow with the eight amphipods shuffled between the rooms. The burrow is
+// always the same size, so the scale is ignored.
+func day23(b *builder) {
+	amphipods := []byte("AABBCCDD")
+	b.rng.Shuffle(len(amphipods), func(i, j int) { amphipods[i], amphipods[j] = amphipods[j], amphipods[i] })
+
+	b.printf("#############\n")
+	b.printf("#...........#\n")
+	b.printf("###%c#%c#%c#%c###\n", amphipods[0], amphipods[1], amphipods[2], amphipods[3])
+	b.printf("  #%c#%c#%c#%c#\n", amphipods[4], amphipods[5], amphipods[6], amphipods[7])
+	b.printf("  #########\n")
+}
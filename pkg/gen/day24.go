@@ -0,0 +1,65 @@
+package gen
+
+// monadBlock is the code MONAD runs for each digit. It either pushes the digit plus an
+// offset onto a base 26 stack held in z, or pops the top of the stack and pushes again
+// unless the digit matches it plus a check value.
+const monadBlock = `inp w
+mul x 0
+add x z
+mod x 26
+div z %d
+add x %d
+eql x w
+eql x 0
+mul y 0
+add y 25
+mul y x
+add y 1
+mul z y
+mul y 0
+add y w
+add y %d
+mul y x
+add z y
+`
+
+// day24 writes a MONAD program in the same shape as the puzzle's, for a model number
+// with scale digits, rounded up to an even number. Half the blocks push and half pop,
+// pairing the digits up, so both answers are known from the pairs.
+func day24(b *builder) {
+	n := (b.scale + 1) / 2 * 2
+
+	highest := make([]byte, n)
+	lowest := make([]byte, n)
+	offsets := make([]int, n)
+	stack := make([]int, 0, n/2)
+	pushes := 0
+
+	for i := 0; i < n; i++ {
+		offsets[i] = b.between(1, 16)
+
+		if len(stack) == 0 || (pushes < n/2 && b.rng.Intn(2) == 0) {
+			stack = append(stack, i)
+			pushes++
+			b.printf(monadBlock, 1, b.between(10, 16), offsets[i])
+			continue
+		}
+
+		// digit i must equal the pushed digit plus delta
+		pushed := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		delta := b.between(-8, 8)
+		b.printf(monadBlock, 26, delta-offsets[pushed], offsets[i])
+
+		if delta >= 0 {
+			highest[pushed], highest[i] = byte('9'-delta), '9'
+			lowest[pushed], lowest[i] = '1', byte('1'+delta)
+		} else {
+			highest[pushed], highest[i] = '9', byte('9'+delta)
+			lowest[pushed], lowest[i] = byte('1'-delta), '1'
+		}
+	}
+
+	b.answers[1] = string(highest)
+	b.answers[2] = string(lowest)
+}
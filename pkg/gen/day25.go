@@ -0,0 +1,6 @@
+package gen
+
+// day25 writes a sea floor with east and south facing herds of sea cucumbers
+func day25(b *builder) {
+	b.grid(b.scale, "....>>>vvv")
+}
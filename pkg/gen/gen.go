@@ -0,0 +1,150 @@
+// Package gen generates random, valid puzzle inputs for every day. Generators are seeded,
+// so the same options always give the same input, and take a scale that sets the size of
+// the input, from tiny examples to far beyond what the puzzle itself asks for.
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+)
+
+// Options control what is generated
+type Options struct {
+	Seed  int64
+	Scale int // size of the input, with a meaning particular to each day. 0 is puzzle sized.
+}
+
+// Input is a generated puzzle input. Answers holds the answers to each part, keyed by
+// part number, for days where the generator knows them from how the input was built.
+type Input struct {
+	Day     int
+	Text    []byte
+	Answers map[int]string
+}
+
+// generator describes how one day's input is built
+type generator struct {
+	build func(b *builder)
+	scale int    // puzzle sized scale
+	about string // what the scale means
+}
+
+var generators = map[int]generator{
+	1:  {day01, 2000, "number of depth measurements"},
+	2:  {day02, 1000, "number of commands"},
+	3:  {day03, 1000, "number of diagnostic values"},
+	4:  {day04, 100, "number of bingo boards"},
+	5:  {day05, 500, "number of vent lines"},
+	6:  {day06, 300, "number of lanternfish"},
+	7:  {day07, 1000, "number of crabs"},
+	8:  {day08, 200, "number of displays"},
+	9:  {day09, 100, "width and height of the heightmap"},
+	10: {day10, 100, "number of navigation lines"},
+	11: {day11, 10, "width and height of the octopus grid"},
+	12: {day12, 12, "number of caves"},
+	13: {day13, 800, "number of dots"},
+	14: {day14, 20, "length of the polymer template"},
+	15: {day15, 100, "width and height of the cavern"},
+	16: {day16, 60, "number of packets"},
+	17: {day17, 100, "distance to the target area"},
+	18: {day18, 100, "number of snailfish numbers"},
+	19: {day19, 26, "number of scanners"},
+	20: {day20, 100, "width and height of the image"},
+	21: {day21, 1, "unused, the board always has 10 spaces"},
+	22: {day22, 420, "number of reboot steps"},
+	23: {day23, 1, "unused, the burrow always holds 8 amphipods"},
+	24: {day24, 14, "number of digits in the model number"},
+	25: {day25, 137, "width and height of the sea floor"},
+}
+
+// Days returns the days that have a generator, in order
+func Days() []int {
+	days := make([]int, 0, len(generators))
+	for day := range generators {
+		days = append(days, day)
+	}
+	sort.Ints(days)
+
+	return days
+}
+
+// DefaultScale returns the puzzle sized scale for a day, and what the scale means
+func DefaultScale(day int) (int, string, error) {
+	g, ok := generators[day]
+	if !ok {
+		return 0, "", fmt.Errorf("no generator for day %d", day)
+	}
+
+	return g.scale, g.about, nil
+}
+
+// Generate builds a random input for a day
+func Generate(day int, opts Options) (*Input, error) {
+	g, ok := generators[day]
+	if !ok {
+		return nil, fmt.Errorf("no generator for day %d", day)
+	}
+	if opts.Scale < 0 {
+		return nil, fmt.Errorf("invalid scale %d", opts.Scale)
+	}
+
+	b := &builder{
+		rng:     rand.New(rand.NewSource(opts.Seed)),
+		scale:   opts.Scale,
+		answers: make(map[int]string),
+	}
+	if b.scale == 0 {
+		b.scale = g.scale
+	}
+	g.build(b)
+
+	return &Input{Day: day, Text: b.buf.Bytes(), Answers: b.answers}, nil
+}
+
+// builder collects the text of an input as a generator writes it
+type builder struct {
+	rng     *rand.Rand
+	scale   int
+	buf     bytes.Buffer
+	answers map[int]string
+}
+
+func (b *builder) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&b.buf, format, args...)
+}
+
+// answer records the known answer to a part
+func (b *builder) answer(part, val int) {
+	b.answers[part] = strconv.Itoa(val)
+}
+
+// between returns a random number from lo to hi inclusive
+func (b *builder) between(lo, hi int) int {
+	return lo + b.rng.Intn(hi-lo+1)
+}
+
+// pick returns a random byte of s
+func (b *builder) pick(s string) byte {
+	return s[b.rng.Intn(len(s))]
+}
+
+// grid writes a size by size grid of random characters from chars
+func (b *builder) grid(size int, chars string) {
+	for y := 0; y < size; y++ {
+		row := make([]byte, size)
+		for x := range row {
+			row[x] = b.pick(chars)
+		}
+		b.printf("%s\n", row)
+	}
+}
+
+func abs(i int) int {
+	if i < 0 {
+		return -i
+	}
+	return i
+}
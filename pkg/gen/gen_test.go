@@ -0,0 +1,156 @@
+package gen_test
+
+import (
+	"bytes"
+	"context"
+	"github.com/ryderlewis/aoc2021/pkg/challenge"
+	"github.com/ryderlewis/aoc2021/pkg/gen"
+	_ "github.com/ryderlewis/aoc2021/pkg/solutions"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// TestGenerateParses checks that every generated input is accepted by its day's parser,
+// from the smallest scale up to puzzle sized
+func TestGenerateParses(t *testing.T) {
+	for _, day := range gen.Days() {
+		for _, scale := range []int{1, 2, 5, 0} {
+			for seed := int64(1); seed <= 3; seed++ {
+				input, err := gen.Generate(day, gen.Options{Seed: seed, Scale: scale})
+				if err != nil {
+					t.Fatalf("day %d: %v", day, err)
+				}
+
+				dc, err := challenge.Lookup(2021, day)
+				if err != nil {
+					t.Fatal(err)
+				}
+				pc, ok := dc.(challenge.PhasedChallenge)
+				if !ok {
+					t.Fatalf("day %d doesn't implement PhasedChallenge", day)
+				}
+				if err := pc.Load(bytes.NewReader(input.Text)); err != nil {
+					t.Errorf("day %d, scale %d, seed %d: %v", day, scale, seed, err)
+				}
+			}
+		}
+	}
+}
+
+func TestGenerateSeeded(t *testing.T) {
+	for _, day := range gen.Days() {
+		a, _ := gen.Generate(day, gen.Options{Seed: 42})
+		b, _ := gen.Generate(day, gen.Options{Seed: 42})
+		if !bytes.Equal(a.Text, b.Text) {
+			t.Errorf("day %d: same seed gave different inputs", day)
+		}
+	}
+
+	a, _ := gen.Generate(5, gen.Options{Seed: 1})
+	b, _ := gen.Generate(5, gen.Options{Seed: 2})
+	if bytes.Equal(a.Text, b.Text) {
+		t.Errorf("day 5: different seeds gave the same input")
+	}
+}
+
+// TestGenerateAnswers solves the inputs whose answers are known from how they were built
+func TestGenerateAnswers(t *testing.T) {
+	tests := []struct {
+		day, scale int
+	}{
+		{16, 10},
+		{16, 0},
+		{19, 5},
+	}
+
+	for _, test := range tests {
+		for seed := int64(1); seed <= 3; seed++ {
+			input, err := gen.Generate(test.day, gen.Options{Seed: seed, Scale: test.scale})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for part := 1; part <= 2; part++ {
+				want, ok := input.Answers[part]
+				if !ok {
+					t.Fatalf("day %d: no answer for part %d", test.day, part)
+				}
+
+				dc, _ := challenge.Lookup(2021, test.day)
+				result, err := challenge.Solve(context.Background(), dc, part, bytes.NewReader(input.Text))
+				if err != nil {
+					t.Errorf("day %d, part %d, seed %d: %v", test.day, part, seed, err)
+					continue
+				}
+				if result.Answer != want {
+					t.Errorf("day %d, part %d, seed %d: got %s, want %s", test.day, part, seed, result.Answer, want)
+				}
+			}
+		}
+	}
+}
+
+// TestGenerateMONAD runs the generated programs directly, rather than through day 24's
+// solver, which is tuned to the real puzzle and can't solve deeply nested programs
+func TestGenerateMONAD(t *testing.T) {
+	for _, scale := range []int{2, 14, 30} {
+		for seed := int64(1); seed <= 5; seed++ {
+			input, err := gen.Generate(24, gen.Options{Seed: seed, Scale: scale})
+			if err != nil {
+				t.Fatal(err)
+			}
+			program := strings.Split(strings.TrimSpace(string(input.Text)), "\n")
+
+			for part := 1; part <= 2; part++ {
+				model := input.Answers[part]
+				if len(model) != scale {
+					t.Errorf("scale %d, seed %d: got model number %q, want %d digits", scale, seed, model, scale)
+				}
+				if z := monad(t, program, model); z != 0 {
+					t.Errorf("scale %d, seed %d: %s left z = %d, want 0", scale, seed, model, z)
+				}
+			}
+		}
+	}
+}
+
+// monad runs an ALU program on the digits of model, returning z
+func monad(t *testing.T, program []string, model string) int {
+	vars := make(map[string]int)
+	for _, line := range program {
+		fields := strings.Fields(line)
+		if fields[0] == "inp" {
+			vars[fields[1]] = int(model[0] - '0')
+			model = model[1:]
+			continue
+		}
+
+		b, err := strconv.Atoi(fields[2])
+		if err != nil {
+			b = vars[fields[2]]
+		}
+		a := vars[fields[1]]
+		switch fields[0] {
+		case "add":
+			a += b
+		case "mul":
+			a *= b
+		case "div":
+			a /= b
+		case "mod":
+			a %= b
+		case "eql":
+			if a == b {
+				a = 1
+			} else {
+				a = 0
+			}
+		default:
+			t.Fatalf("unknown instruction %q", line)
+		}
+		vars[fields[1]] = a
+	}
+
+	return vars["z"]
+}
@@ -0,0 +1,209 @@
+// Package grid is a dense two dimensional grid of ints, for the puzzles set on a map of
+// digits or characters. Grids are read from rows of text, where each character stands
+// for its index in a set of characters, and can be rendered back the same way.
+package grid
+
+import (
+	"github.com/ryderlewis/aoc2021/pkg/parse"
+	"strings"
+)
+
+// Digits are the characters of a grid of single digits, each standing for its own value
+const Digits = "0123456789"
+
+// Point is a position on a grid, or a step from one position to another. X runs left to
+// right and Y top to bottom.
+type Point struct {
+	X, Y int
+}
+
+// Add returns the point reached by taking step d from p
+func (p Point) Add(d Point) Point {
+	return Point{X: p.X + d.X, Y: p.Y + d.Y}
+}
+
+var (
+	// Orthogonal are the steps to the 4 neighbors up, left, right and down
+	Orthogonal = []Point{{0, -1}, {-1, 0}, {1, 0}, {0, 1}}
+
+	// Surrounding are the steps to all 8 neighbors, including diagonals, in reading order
+	Surrounding = []Point{{-1, -1}, {0, -1}, {1, -1}, {-1, 0}, {1, 0}, {-1, 1}, {0, 1}, {1, 1}}
+)
+
+// Grid is a Width by Height grid of values. Stepping off an edge leaves the grid, unless
+// Wrap is set, in which case it comes back on the opposite edge.
+type Grid struct {
+	Width, Height int
+	Wrap          bool
+
+	cells []int
+}
+
+// New returns a grid with every value 0
+func New(width, height int) *Grid {
+	return &Grid{Width: width, Height: height, cells: make([]int, width*height)}
+}
+
+// Read reads the rows of a grid up to the end of the input. Each character must be one
+// of chars, and is stored as its index in chars. Every row must be the same length, and
+// want describes a row, for the error when there are none.
+func Read(scanner *parse.Scanner, chars, want string) (*Grid, error) {
+	g := &Grid{}
+
+	for scanner.Scan() {
+		line := scanner.Field()
+		if err := line.Only(chars); err != nil {
+			return nil, err
+		}
+		if len(line.Text) == 0 || (g.Height > 0 && len(line.Text) != g.Width) {
+			return nil, scanner.Errorf("%w: want a row the same length as the first", parse.ErrSyntax)
+		}
+
+		g.Width = len(line.Text)
+		g.Height++
+		for i := 0; i < len(line.Text); i++ {
+			g.cells = append(g.cells, strings.IndexByte(chars, line.Text[i]))
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if g.Height == 0 {
+		return nil, scanner.Truncated(want)
+	}
+	return g, nil
+}
+
+// Copy returns a copy of the grid that can be changed freely
+func (g *Grid) Copy() *Grid {
+	c := *g
+	c.cells = make([]int, len(g.cells))
+	copy(c.cells, g.cells)
+
+	return &c
+}
+
+// In reports whether p is on the grid
+func (g *Grid) In(p Point) bool {
+	return p.X >= 0 && p.X < g.Width && p.Y >= 0 && p.Y < g.Height
+}
+
+// Get returns the value at p, which must be on the grid
+func (g *Grid) Get(p Point) int {
+	return g.cells[p.X+p.Y*g.Width]
+}
+
+// GetOr returns the value at p, or def if p is off the grid
+func (g *Grid) GetOr(p Point, def int) int {
+	if !g.In(p) {
+		return def
+	}
+	return g.Get(p)
+}
+
+// Set changes the value at p, which must be on the grid
+func (g *Grid) Set(p Point, v int) {
+	g.cells[p.X+p.Y*g.Width] = v
+}
+
+// Add adds d to the value at p, which must be on the grid
+func (g *Grid) Add(p Point, d int) {
+	g.cells[p.X+p.Y*g.Width] += d
+}
+
+// Fill sets every value to v
+func (g *Grid) Fill(v int) {
+	for i := range g.cells {
+		g.cells[i] = v
+	}
+}
+
+// Count returns how many values match
+func (g *Grid) Count(match func(v int) bool) int {
+	count := 0
+	for _, v := range g.cells {
+		if match(v) {
+			count++
+		}
+	}
+
+	return count
+}
+
+// Points returns every point on the grid, in reading order
+func (g *Grid) Points() []Point {
+	points := make([]Point, 0, len(g.cells))
+	for y := 0; y < g.Height; y++ {
+		for x := 0; x < g.Width; x++ {
+			points = append(points, Point{X: x, Y: y})
+		}
+	}
+
+	return points
+}
+
+// Step returns the point reached by taking step d from p, and whether it is on the grid.
+// On a wrapping grid it always is.
+func (g *Grid) Step(p, d Point) (Point, bool) {
+	n := p.Add(d)
+	if g.Wrap {
+		n.X = ((n.X % g.Width) + g.Width) % g.Width
+		n.Y = ((n.Y % g.Height) + g.Height) % g.Height
+	}
+
+	return n, g.In(n)
+}
+
+// Neighbors returns the points reached by each of steps from p that are on the grid
+func (g *Grid) Neighbors(p Point, steps []Point) []Point {
+	neighbors := make([]Point, 0, len(steps))
+	for _, d := range steps {
+		if n, ok := g.Step(p, d); ok {
+			neighbors = append(neighbors, n)
+		}
+	}
+
+	return neighbors
+}
+
+// FloodFill returns the points reachable from start by repeatedly taking one of steps,
+// where spread reports whether the fill can go from one point on to its neighbor. start
+// comes first, then the rest in the order they were reached.
+func (g *Grid) FloodFill(start Point, steps []Point, spread func(from, to Point) bool) []Point {
+	filled := []Point{start}
+	seen := map[Point]bool{start: true}
+
+	for i := 0; i < len(filled); i++ {
+		from := filled[i]
+		for _, to := range g.Neighbors(from, steps) {
+			if seen[to] || !spread(from, to) {
+				continue
+			}
+			seen[to] = true
+			filled = append(filled, to)
+		}
+	}
+
+	return filled
+}
+
+// Render returns the grid as rows of text, each value written as the character at that
+// index in chars, or '?' if there is none
+func (g *Grid) Render(chars string) string {
+	var b strings.Builder
+	b.Grow((g.Width + 1) * g.Height)
+
+	for y := 0; y < g.Height; y++ {
+		for _, v := range g.cells[y*g.Width : (y+1)*g.Width] {
+			if v >= 0 && v < len(chars) {
+				b.WriteByte(chars[v])
+			} else {
+				b.WriteByte('?')
+			}
+		}
+		b.WriteByte('\n')
+	}
+
+	return b.String()
+}
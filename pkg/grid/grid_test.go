@@ -0,0 +1,129 @@
+package grid
+
+import (
+	"errors"
+	"github.com/ryderlewis/aoc2021/pkg/parse"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRead(t *testing.T) {
+	g, err := Read(parse.NewScanner(strings.NewReader("123\n456\n")), Digits, "a row")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.Width != 3 || g.Height != 2 {
+		t.Errorf("got %dx%d, want 3x2", g.Width, g.Height)
+	}
+	if got := g.Get(Point{X: 2, Y: 1}); got != 6 {
+		t.Errorf("got %d at 2,1, want 6", got)
+	}
+	if got := g.Render(Digits); got != "123\n456\n" {
+		t.Errorf("got %q, want %q", got, "123\n456\n")
+	}
+
+	g, err = Read(parse.NewScanner(strings.NewReader(".>\nv.\n")), ".>v", "a row")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{0, 1, 2, 0}; !reflect.DeepEqual(g.cells, want) {
+		t.Errorf("got %v, want %v", g.cells, want)
+	}
+}
+
+func TestReadErrors(t *testing.T) {
+	tests := []struct {
+		input        string
+		line, column int
+		err          error
+	}{
+		{"12\n1x\n", 2, 2, parse.ErrCharacter},
+		{"12\n123\n", 2, 0, parse.ErrSyntax},
+		{"12\n\n12\n", 2, 0, parse.ErrSyntax},
+		{"", 1, 0, parse.ErrTruncated},
+	}
+
+	for _, test := range tests {
+		_, err := Read(parse.NewScanner(strings.NewReader(test.input)), Digits, "a row")
+		var perr *parse.Error
+		if !errors.As(err, &perr) || !errors.Is(err, test.err) {
+			t.Errorf("%q: got %v, want %v", test.input, err, test.err)
+			continue
+		}
+		if perr.Line != test.line || perr.Column != test.column {
+			t.Errorf("%q: got line %d, column %d, want line %d, column %d", test.input, perr.Line, perr.Column, test.line, test.column)
+		}
+	}
+}
+
+func TestNeighbors(t *testing.T) {
+	g := New(3, 2)
+
+	tests := []struct {
+		p     Point
+		steps []Point
+		wrap  bool
+		want  []Point
+	}{
+		{Point{0, 0}, Orthogonal, false, []Point{{1, 0}, {0, 1}}},
+		{Point{1, 1}, Surrounding, false, []Point{{0, 0}, {1, 0}, {2, 0}, {0, 1}, {2, 1}}},
+		{Point{0, 0}, Orthogonal, true, []Point{{0, 1}, {2, 0}, {1, 0}, {0, 1}}},
+		{Point{2, 1}, []Point{{1, 1}}, true, []Point{{0, 0}}},
+	}
+
+	for _, test := range tests {
+		g.Wrap = test.wrap
+		if got := g.Neighbors(test.p, test.steps); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Neighbors(%v, %v) with wrap %v = %v, want %v", test.p, test.steps, test.wrap, got, test.want)
+		}
+	}
+}
+
+func TestFloodFill(t *testing.T) {
+	g, err := Read(parse.NewScanner(strings.NewReader("119\n919\n111\n")), Digits, "a row")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	low := func(from, to Point) bool {
+		return g.Get(to) < 9
+	}
+	got := g.FloodFill(Point{0, 0}, Orthogonal, low)
+	want := []Point{{0, 0}, {1, 0}, {1, 1}, {1, 2}, {0, 2}, {2, 2}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	// only diagonals connect the corners
+	g, err = Read(parse.NewScanner(strings.NewReader("191\n919\n191\n")), Digits, "a row")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := g.FloodFill(Point{0, 0}, Orthogonal, low); len(got) != 1 {
+		t.Errorf("got %d points without diagonals, want 1", len(got))
+	}
+	if got := g.FloodFill(Point{0, 0}, Surrounding, low); len(got) != 5 {
+		t.Errorf("got %d points with diagonals, want 5", len(got))
+	}
+}
+
+func TestCopy(t *testing.T) {
+	g := New(2, 2)
+	c := g.Copy()
+	c.Set(Point{1, 1}, 5)
+	c.Add(Point{1, 1}, 1)
+
+	if g.Get(Point{1, 1}) != 0 {
+		t.Errorf("changing the copy changed the original")
+	}
+	if got := c.GetOr(Point{1, 1}, -1); got != 6 {
+		t.Errorf("got %d, want 6", got)
+	}
+	if got := c.GetOr(Point{2, 1}, -1); got != -1 {
+		t.Errorf("got %d off the grid, want -1", got)
+	}
+	if got := c.Count(func(v int) bool { return v == 0 }); got != 3 {
+		t.Errorf("got %d zeros, want 3", got)
+	}
+}
@@ -0,0 +1,46 @@
+package inputs
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// AnswersFile is the name of the answer manifest within each year's input directory
+const AnswersFile = "answers.json"
+
+// Answers holds the known-good answers for the input files, keyed by day, then part,
+// then the key AnswerKey gives the input
+type Answers map[int]map[int]map[string]string
+
+// AnswerKey returns the key of the answers for a day's example or real input. It is the
+// file name, followed by # and the example's name for an example file holding several,
+// such as a12.txt#larger.
+func AnswerKey(day int, example bool, name string) string {
+	key := Name(day, example)
+	if name != "" {
+		key += "#" + name
+	}
+	return key
+}
+
+// LoadAnswers reads the answer manifest for a year from dir
+func LoadAnswers(dir string, year int) (Answers, error) {
+	data, err := os.ReadFile(filepath.Join(YearDir(dir, year), AnswersFile))
+	if err != nil {
+		return nil, err
+	}
+
+	answers := make(Answers)
+	if err := json.Unmarshal(data, &answers); err != nil {
+		return nil, err
+	}
+
+	return answers, nil
+}
+
+// Lookup returns the expected answer for a day, part and input, keyed as AnswerKey does
+func (a Answers) Lookup(day, part int, key string) (string, bool) {
+	answer, ok := a[day][part][key]
+	return answer, ok
+}
@@ -0,0 +1,99 @@
+package inputs
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ExampleMarker starts a line naming the example that follows it, in an example file
+// holding several of them:
+//
+//	=== small
+//	start-A
+//	...
+//	=== larger
+//	dc-end
+//	...
+const ExampleMarker = "==="
+
+// Example is one example from an example file. A file without markers holds a single
+// example with no name.
+type Example struct {
+	Name string
+	Text []byte
+}
+
+// SplitExamples splits the contents of an example file into its examples, in the order
+// they appear
+func SplitExamples(data []byte) ([]Example, error) {
+	if !bytes.HasPrefix(data, []byte(ExampleMarker)) && !bytes.Contains(data, []byte("\n"+ExampleMarker)) {
+		return []Example{{Text: data}}, nil
+	}
+
+	examples := make([]Example, 0)
+	seen := make(map[string]bool)
+	for i, line := range bytes.SplitAfter(data, []byte("\n")) {
+		if !bytes.HasPrefix(line, []byte(ExampleMarker)) {
+			if len(examples) == 0 {
+				if len(bytes.TrimSpace(line)) > 0 {
+					return nil, fmt.Errorf("line %d: text before the first %s marker", i+1, ExampleMarker)
+				}
+				continue
+			}
+			last := &examples[len(examples)-1]
+			last.Text = append(last.Text, line...)
+			continue
+		}
+
+		name := strings.Trim(string(line), "= \r\n")
+		switch {
+		case name == "" || strings.ContainsAny(name, " /#"):
+			return nil, fmt.Errorf("line %d: invalid example name %q, want a single word", i+1, name)
+		case seen[name]:
+			return nil, fmt.Errorf("line %d: example %q appears twice", i+1, name)
+		}
+		seen[name] = true
+		examples = append(examples, Example{Name: name})
+	}
+
+	for _, ex := range examples {
+		if len(bytes.TrimSpace(ex.Text)) == 0 {
+			return nil, fmt.Errorf("example %q is empty", ex.Name)
+		}
+	}
+
+	return examples, nil
+}
+
+// ReadExamples reads the examples from an example file
+func ReadExamples(path string) ([]Example, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	examples, err := SplitExamples(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return examples, nil
+}
+
+// FindExample returns the example named name
+func FindExample(examples []Example, name string) (Example, error) {
+	names := make([]string, 0, len(examples))
+	for _, ex := range examples {
+		if ex.Name == name {
+			return ex, nil
+		}
+		names = append(names, ex.Name)
+	}
+
+	if len(names) == 1 && names[0] == "" {
+		return Example{}, fmt.Errorf("no example %q, the file holds a single example", name)
+	}
+	return Example{}, fmt.Errorf("no example %q, want one of %s", name, strings.Join(names, ", "))
+}
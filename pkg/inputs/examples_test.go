@@ -0,0 +1,52 @@
+package inputs
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSplitExamples(t *testing.T) {
+	data := "\n=== small\nstart-A\nA-end\n=== larger ===\ndc-end\n\nHN-start\n"
+	examples, err := SplitExamples([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(examples) != 2 {
+		t.Fatalf("got %d examples, want 2", len(examples))
+	}
+	if examples[0].Name != "small" || string(examples[0].Text) != "start-A\nA-end\n" {
+		t.Errorf("got %q: %q", examples[0].Name, examples[0].Text)
+	}
+	if examples[1].Name != "larger" || string(examples[1].Text) != "dc-end\n\nHN-start\n" {
+		t.Errorf("got %q: %q", examples[1].Name, examples[1].Text)
+	}
+
+	if _, err := FindExample(examples, "largest"); err == nil || !strings.Contains(err.Error(), "small, larger") {
+		t.Errorf("got %v, want an error listing the examples", err)
+	}
+}
+
+func TestSplitUnmarked(t *testing.T) {
+	examples, err := SplitExamples([]byte("199\n200\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(examples) != 1 || examples[0].Name != "" || string(examples[0].Text) != "199\n200\n" {
+		t.Errorf("got %+v", examples)
+	}
+}
+
+func TestSplitErrors(t *testing.T) {
+	for _, data := range []string{
+		"199\n=== small\n200\n",
+		"===\n199\n",
+		"=== two words\n199\n",
+		"=== small\n199\n=== small\n200\n",
+		"=== small\n199\n=== empty\n\n",
+	} {
+		if _, err := SplitExamples([]byte(data)); err == nil {
+			t.Errorf("SplitExamples(%q): got no error", data)
+		}
+	}
+}
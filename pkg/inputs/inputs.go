@@ -0,0 +1,50 @@
+// Package inputs locates puzzle input files. Every day has an example input named aNN.txt
+// and a real puzzle input named bNN.txt, kept in a directory for each year, such as
+// inputs/2021/b01.txt. An example file may hold several examples, each under a marker
+// naming it.
+package inputs
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// DefaultDir is the input directory, relative to the repository root
+const DefaultDir = "inputs"
+
+// YearDir returns the directory holding a year's inputs within dir
+func YearDir(dir string, year int) string {
+	return filepath.Join(dir, strconv.Itoa(year))
+}
+
+// Path returns the path of a day's example or real input file for a year within dir
+func Path(dir string, year, day int, example bool) string {
+	return filepath.Join(YearDir(dir, year), Name(day, example))
+}
+
+// Find returns the path of a day's example or real input file for a year within dir,
+// or an error saying which input is missing
+func Find(dir string, year, day int, example bool) (string, error) {
+	path := Path(dir, year, day, example)
+	if _, err := os.Stat(path); err != nil {
+		kind := "real"
+		if example {
+			kind = "example"
+		}
+		return "", fmt.Errorf("no %s input for %d day %d: %w", kind, year, day, err)
+	}
+
+	return path, nil
+}
+
+// Name returns the file name of a day's example or real input
+func Name(day int, example bool) string {
+	prefix := "b"
+	if example {
+		prefix = "a"
+	}
+
+	return fmt.Sprintf("%s%02d.txt", prefix, day)
+}
@@ -0,0 +1,37 @@
+package inputs
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFind(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(YearDir(dir, 2021), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(Path(dir, 2021, 1, true), []byte("199\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if path, err := Find(dir, 2021, 1, true); err != nil || path != filepath.Join(dir, "2021", "a01.txt") {
+		t.Errorf("got %s, %v", path, err)
+	}
+
+	_, err := Find(dir, 2021, 1, false)
+	if !errors.Is(err, os.ErrNotExist) || !strings.HasPrefix(err.Error(), "no real input for 2021 day 1") {
+		t.Errorf("got %v", err)
+	}
+}
+
+func TestAnswerKey(t *testing.T) {
+	if got := AnswerKey(12, true, "larger"); got != "a12.txt#larger" {
+		t.Errorf("got %s, want a12.txt#larger", got)
+	}
+	if got := AnswerKey(12, false, ""); got != "b12.txt" {
+		t.Errorf("got %s, want b12.txt", got)
+	}
+}
@@ -0,0 +1,275 @@
+// Package parse reads puzzle input line by line, and reports malformed input as an
+// *Error giving the line, column and text where the problem was found.
+package parse
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var (
+	ErrInteger    = errors.New("invalid integer")
+	ErrFieldCount = errors.New("wrong number of fields")
+	ErrCharacter  = errors.New("unexpected character")
+	ErrTruncated  = errors.New("unexpected end of input")
+	ErrSyntax     = errors.New("syntax error")
+)
+
+// Error describes malformed input. Err wraps one of the Err values above, so callers
+// can test for the kind of problem with errors.Is.
+type Error struct {
+	Line   int    // line number, counting from 1
+	Column int    // column number, counting from 1, or 0 if the whole line is at fault
+	Text   string // offending text
+	Err    error
+}
+
+func (e *Error) Error() string {
+	var b strings.Builder
+
+	_, _ = fmt.Fprintf(&b, "line %d", e.Line)
+	if e.Column > 0 {
+		_, _ = fmt.Fprintf(&b, ", column %d", e.Column)
+	}
+	_, _ = fmt.Fprintf(&b, ": %v", e.Err)
+	if e.Text != "" {
+		_, _ = fmt.Fprintf(&b, ": %q", e.Text)
+	}
+
+	return b.String()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Field is a piece of a line of input, along with where it was found
+type Field struct {
+	Text   string
+	Line   int
+	Column int
+}
+
+// Errorf returns an *Error pointing at the field. The message should wrap one of the Err
+// values with %w.
+func (f Field) Errorf(format string, args ...interface{}) error {
+	return &Error{Line: f.Line, Column: f.Column, Text: f.Text, Err: fmt.Errorf(format, args...)}
+}
+
+// Int parses the field as a base 10 integer
+func (f Field) Int() (int, error) {
+	i, err := strconv.Atoi(f.Text)
+	if err != nil {
+		if errors.Is(err, strconv.ErrRange) {
+			return 0, f.Errorf("%w: out of range", ErrInteger)
+		}
+		return 0, f.Errorf("%w", ErrInteger)
+	}
+
+	return i, nil
+}
+
+// Ints parses every sep separated piece of the field as an integer
+func (f Field) Ints(sep string) ([]int, error) {
+	pieces := f.Split(sep)
+	ints := make([]int, len(pieces))
+
+	for i, piece := range pieces {
+		val, err := piece.Int()
+		if err != nil {
+			return nil, err
+		}
+		ints[i] = val
+	}
+
+	return ints, nil
+}
+
+// Slice returns the part of the field from byte i up to but not including byte j
+func (f Field) Slice(i, j int) Field {
+	return Field{Text: f.Text[i:j], Line: f.Line, Column: f.Column + i}
+}
+
+// Split splits the field around each instance of sep
+func (f Field) Split(sep string) []Field {
+	fields := make([]Field, 0)
+
+	start := 0
+	for {
+		i := strings.Index(f.Text[start:], sep)
+		if i < 0 || sep == "" {
+			break
+		}
+		fields = append(fields, f.Slice(start, start+i))
+		start += i + len(sep)
+	}
+	fields = append(fields, f.Slice(start, len(f.Text)))
+
+	return fields
+}
+
+// SplitN splits the field around sep, which must give exactly n pieces
+func (f Field) SplitN(sep string, n int) ([]Field, error) {
+	fields := f.Split(sep)
+	if len(fields) != n {
+		return nil, f.Errorf("%w: want %d separated by %q, got %d", ErrFieldCount, n, sep, len(fields))
+	}
+
+	return fields, nil
+}
+
+// Fields splits the field around runs of white space
+func (f Field) Fields() []Field {
+	fields := make([]Field, 0)
+
+	start := -1
+	for i, c := range f.Text {
+		if unicode.IsSpace(c) {
+			if start >= 0 {
+				fields = append(fields, f.Slice(start, i))
+				start = -1
+			}
+		} else if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		fields = append(fields, f.Slice(start, len(f.Text)))
+	}
+
+	return fields
+}
+
+// FieldsN splits the field around runs of white space, which must give exactly n fields
+func (f Field) FieldsN(n int) ([]Field, error) {
+	fields := f.Fields()
+	if len(fields) != n {
+		return nil, f.Errorf("%w: want %d, got %d", ErrFieldCount, n, len(fields))
+	}
+
+	return fields, nil
+}
+
+// Only checks that every byte in the field is one of allowed
+func (f Field) Only(allowed string) error {
+	for i := 0; i < len(f.Text); i++ {
+		if strings.IndexByte(allowed, f.Text[i]) < 0 {
+			return f.Slice(i, i+1).Errorf("%w: want one of %q", ErrCharacter, allowed)
+		}
+	}
+
+	return nil
+}
+
+// Match matches the whole field against re, and returns a field for each subexpression.
+// want describes what was expected, for the error when it doesn't match.
+func (f Field) Match(re *regexp.Regexp, want string) ([]Field, error) {
+	loc := re.FindStringSubmatchIndex(f.Text)
+	if loc == nil || loc[0] != 0 || loc[1] != len(f.Text) {
+		return nil, f.Errorf("%w: want %s", ErrSyntax, want)
+	}
+
+	fields := make([]Field, 0, len(loc)/2-1)
+	for i := 2; i < len(loc); i += 2 {
+		if loc[i] < 0 {
+			fields = append(fields, Field{Line: f.Line, Column: f.Column})
+		} else {
+			fields = append(fields, f.Slice(loc[i], loc[i+1]))
+		}
+	}
+
+	return fields, nil
+}
+
+// Scanner reads input a line at a time, keeping track of the line number
+type Scanner struct {
+	scanner *bufio.Scanner
+	line    int
+	text    string
+}
+
+func NewScanner(r io.Reader) *Scanner {
+	return &Scanner{scanner: bufio.NewScanner(r)}
+}
+
+// Scan advances to the next line, returning false at the end of the input or on a
+// read error
+func (s *Scanner) Scan() bool {
+	if !s.scanner.Scan() {
+		return false
+	}
+	s.line++
+	s.text = s.scanner.Text()
+
+	return true
+}
+
+// Text returns the current line
+func (s *Scanner) Text() string {
+	return s.text
+}
+
+// Field returns the whole of the current line
+func (s *Scanner) Field() Field {
+	return Field{Text: s.text, Line: s.line, Column: 1}
+}
+
+// Next advances to the next line and returns it. Running out of input is an error.
+func (s *Scanner) Next() (Field, error) {
+	if !s.Scan() {
+		if err := s.Err(); err != nil {
+			return Field{}, err
+		}
+		return Field{}, s.Truncated("another line")
+	}
+
+	return s.Field(), nil
+}
+
+// Truncated returns an *Error for input that ended early. want describes what was
+// expected next.
+func (s *Scanner) Truncated(want string) error {
+	return &Error{Line: s.line + 1, Err: fmt.Errorf("%w: want %s", ErrTruncated, want)}
+}
+
+// Blank advances to the next line, which must be empty
+func (s *Scanner) Blank() error {
+	f, err := s.Next()
+	if err != nil {
+		return err
+	}
+	if f.Text != "" {
+		return f.Errorf("%w: want a blank line", ErrSyntax)
+	}
+
+	return nil
+}
+
+// End checks that there is nothing left to read
+func (s *Scanner) End() error {
+	if s.Scan() {
+		return s.Errorf("%w: want the end of the input", ErrSyntax)
+	}
+
+	return s.Err()
+}
+
+// Errorf returns an *Error about the whole of the current line
+func (s *Scanner) Errorf(format string, args ...interface{}) error {
+	return &Error{Line: s.line, Text: s.text, Err: fmt.Errorf(format, args...)}
+}
+
+// Err returns the first read error, if any
+func (s *Scanner) Err() error {
+	if err := s.scanner.Err(); err != nil {
+		return &Error{Line: s.line + 1, Err: err}
+	}
+
+	return nil
+}
@@ -0,0 +1,140 @@
+package parse
+
+import (
+	"errors"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestFieldInt(t *testing.T) {
+	f := Field{Text: "x=1a", Line: 3, Column: 5}.Slice(2, 4)
+
+	_, err := f.Int()
+	var perr *Error
+	if !errors.As(err, &perr) {
+		t.Fatalf("got %v, want *Error", err)
+	}
+	if !errors.Is(err, ErrInteger) {
+		t.Errorf("got %v, want ErrInteger", err)
+	}
+	if perr.Line != 3 || perr.Column != 7 || perr.Text != "1a" {
+		t.Errorf("got line %d, column %d, text %q, want line 3, column 7, text \"1a\"", perr.Line, perr.Column, perr.Text)
+	}
+	if got, want := err.Error(), `line 3, column 7: invalid integer: "1a"`; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	if _, err := (Field{Text: "99999999999999999999"}).Int(); !errors.Is(err, ErrInteger) {
+		t.Errorf("got %v, want ErrInteger", err)
+	}
+}
+
+func TestFieldSplit(t *testing.T) {
+	tests := []struct {
+		text    string
+		sep     string
+		texts   []string
+		columns []int
+	}{
+		{"1,2,3", ",", []string{"1", "2", "3"}, []int{1, 3, 5}},
+		{"a -> b", " -> ", []string{"a", "b"}, []int{1, 6}},
+		{"", ",", []string{""}, []int{1}},
+		{",", ",", []string{"", ""}, []int{1, 2}},
+	}
+
+	for _, test := range tests {
+		fields := Field{Text: test.text, Line: 1, Column: 1}.Split(test.sep)
+		texts, columns := unzip(fields)
+		if !reflect.DeepEqual(texts, test.texts) || !reflect.DeepEqual(columns, test.columns) {
+			t.Errorf("Split(%q, %q) = %q at %v, want %q at %v", test.text, test.sep, texts, columns, test.texts, test.columns)
+		}
+	}
+}
+
+func TestFieldFields(t *testing.T) {
+	fields := Field{Text: " 22 13  17", Line: 1, Column: 1}.Fields()
+	texts, columns := unzip(fields)
+	if want := []string{"22", "13", "17"}; !reflect.DeepEqual(texts, want) {
+		t.Errorf("got %q, want %q", texts, want)
+	}
+	if want := []int{2, 5, 9}; !reflect.DeepEqual(columns, want) {
+		t.Errorf("got %v, want %v", columns, want)
+	}
+
+	if _, err := (Field{Text: "a b c"}).FieldsN(2); !errors.Is(err, ErrFieldCount) {
+		t.Errorf("got %v, want ErrFieldCount", err)
+	}
+}
+
+func TestFieldOnly(t *testing.T) {
+	err := Field{Text: "..#x#", Line: 2, Column: 1}.Only(".#")
+
+	var perr *Error
+	if !errors.As(err, &perr) || !errors.Is(err, ErrCharacter) {
+		t.Fatalf("got %v, want ErrCharacter", err)
+	}
+	if perr.Column != 4 || perr.Text != "x" {
+		t.Errorf("got column %d, text %q, want column 4, text \"x\"", perr.Column, perr.Text)
+	}
+}
+
+func TestFieldMatch(t *testing.T) {
+	re := regexp.MustCompile(`x=(-?\d+)\.\.(-?\d+)`)
+
+	fields, err := Field{Text: "x=-5..10", Line: 1, Column: 1}.Match(re, "a range")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	texts, columns := unzip(fields)
+	if want := []string{"-5", "10"}; !reflect.DeepEqual(texts, want) {
+		t.Errorf("got %q, want %q", texts, want)
+	}
+	if want := []int{3, 7}; !reflect.DeepEqual(columns, want) {
+		t.Errorf("got %v, want %v", columns, want)
+	}
+
+	if _, err := (Field{Text: "x=-5..10!"}).Match(re, "a range"); !errors.Is(err, ErrSyntax) {
+		t.Errorf("got %v, want ErrSyntax", err)
+	}
+}
+
+func TestScanner(t *testing.T) {
+	s := NewScanner(strings.NewReader("a\n\nb\nc"))
+
+	f, err := s.Next()
+	if err != nil || f.Text != "a" || f.Line != 1 {
+		t.Fatalf("got %+v, %v, want \"a\" on line 1", f, err)
+	}
+	if err := s.Blank(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := s.Blank(); !errors.Is(err, ErrSyntax) {
+		t.Errorf("got %v, want ErrSyntax", err)
+	}
+
+	if err := s.End(); !errors.Is(err, ErrSyntax) {
+		t.Errorf("got %v, want ErrSyntax", err)
+	}
+
+	_, err = s.Next()
+	var perr *Error
+	if !errors.As(err, &perr) || !errors.Is(err, ErrTruncated) {
+		t.Fatalf("got %v, want ErrTruncated", err)
+	}
+	if perr.Line != 5 {
+		t.Errorf("got line %d, want 5", perr.Line)
+	}
+}
+
+func unzip(fields []Field) ([]string, []int) {
+	texts := make([]string, len(fields))
+	columns := make([]int, len(fields))
+	for i, f := range fields {
+		texts[i] = f.Text
+		columns[i] = f.Column
+	}
+
+	return texts, columns
+}
@@ -0,0 +1,339 @@
+// Package plugin runs solutions written as external programs, in any language, as
+// challenges alongside the native ones. Plugins are listed in a JSON config file:
+//
+//	{
+//	  "plugins": [
+//	    {"year": 2022, "day": 1, "command": ["python3", "day01.py"], "dir": "py", "timeout": "30s"}
+//	  ]
+//	}
+//
+// Each solve runs the command once, from dir, with the puzzle input on stdin and
+// AOC_YEAR, AOC_DAY and AOC_PART set in its environment. The command writes a single
+// JSON object to stdout, either {"answer": "1234"} or {"error": "what went wrong"}. The
+// answer may also be a number, and an "artifacts" list may come with it, in the same
+// form as in challenge.Result. A command that exits with an error fails the solve, and
+// the end of what it wrote to stderr is kept in the error. A command that runs out of
+// time is killed, along with any processes it started.
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/ryderlewis/aoc2021/pkg/challenge"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultConfig is the name of the config file, relative to the repository root
+	DefaultConfig = "plugins.json"
+
+	// DefaultTimeout is how long a plugin may take to solve a part, unless its config
+	// says otherwise
+	DefaultTimeout = time.Minute
+
+	maxStderr = 2048 // bytes of stderr kept for errors
+)
+
+// Config is the contents of a config file
+type Config struct {
+	Plugins []Plugin `json:"plugins"`
+}
+
+// Plugin describes an external program solving both parts of a day. Relative paths in
+// Dir and Command, such as ./day01.py, are relative to the config file's directory.
+type Plugin struct {
+	Year    int      `json:"year"`
+	Day     int      `json:"day"`
+	Command []string `json:"command"`           // the program and its arguments
+	Dir     string   `json:"dir,omitempty"`     // the directory to run in
+	Timeout string   `json:"timeout,omitempty"` // how long each part may take, such as 30s
+}
+
+// Error is returned when a plugin fails to give an answer or an error of its own
+type Error struct {
+	Command string // the command that was run
+	Err     error
+	Stderr  string // the end of what the command wrote to stderr
+}
+
+func (e *Error) Error() string {
+	msg := fmt.Sprintf("plugin %s: %v", e.Command, e.Err)
+	if e.Stderr != "" {
+		msg += ": " + e.Stderr
+	}
+	return msg
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Load reads a config file, resolving the relative paths in it against the file's
+// directory
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &Config{}
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	base := filepath.Dir(path)
+	for i := range cfg.Plugins {
+		p := &cfg.Plugins[i]
+		if p.Dir == "" || !filepath.IsAbs(p.Dir) {
+			p.Dir = filepath.Join(base, p.Dir)
+		}
+		if len(p.Command) > 0 && strings.ContainsRune(p.Command[0], filepath.Separator) && !filepath.IsAbs(p.Command[0]) {
+			p.Command[0] = filepath.Join(base, p.Command[0])
+		}
+	}
+
+	return cfg, nil
+}
+
+// Register makes every plugin in the config available in the challenge registry. It
+// fails without registering any of them if one is invalid or its day is already taken.
+func (cfg *Config) Register() error {
+	challenges := make([]*Challenge, 0, len(cfg.Plugins))
+	for _, p := range cfg.Plugins {
+		c, err := New(p)
+		if err != nil {
+			return err
+		}
+		if _, err := challenge.Lookup(p.Year, p.Day); err == nil {
+			return fmt.Errorf("plugin for %d day %d: the day already has a solution", p.Year, p.Day)
+		}
+		for _, other := range challenges {
+			if other.plugin.Year == p.Year && other.plugin.Day == p.Day {
+				return fmt.Errorf("plugin for %d day %d: listed twice", p.Year, p.Day)
+			}
+		}
+		challenges = append(challenges, c)
+	}
+
+	for _, c := range challenges {
+		c := *c
+		challenge.Register(c.plugin.Year, c.plugin.Day, func() challenge.DailyChallenge {
+			instance := c
+			return &instance
+		})
+	}
+
+	return nil
+}
+
+// RegisterFile loads a config file and registers its plugins. If the file doesn't exist
+// the error wraps os.ErrNotExist.
+func RegisterFile(path string) error {
+	cfg, err := Load(path)
+	if err != nil {
+		return err
+	}
+	return cfg.Register()
+}
+
+// Challenge solves a day by running a plugin
+type Challenge struct {
+	plugin  Plugin
+	timeout time.Duration
+}
+
+var _ challenge.DailyChallenge = &Challenge{}
+var _ challenge.ContextChallenge = &Challenge{}
+var _ challenge.ResultChallenge = &Challenge{}
+
+// New returns a challenge that runs p
+func New(p Plugin) (*Challenge, error) {
+	if p.Year < 1 || p.Day < 1 || p.Day > 25 {
+		return nil, fmt.Errorf("plugin for %d day %d: invalid year or day", p.Year, p.Day)
+	}
+	if len(p.Command) == 0 || p.Command[0] == "" {
+		return nil, fmt.Errorf("plugin for %d day %d: no command", p.Year, p.Day)
+	}
+
+	c := &Challenge{plugin: p, timeout: DefaultTimeout}
+	if p.Timeout != "" {
+		timeout, err := time.ParseDuration(p.Timeout)
+		if err != nil || timeout <= 0 {
+			return nil, fmt.Errorf("plugin for %d day %d: invalid timeout %q", p.Year, p.Day, p.Timeout)
+		}
+		c.timeout = timeout
+	}
+
+	return c, nil
+}
+
+func (c *Challenge) Challenge1(input io.Reader) (string, error) {
+	return c.Challenge1Context(context.Background(), input)
+}
+
+func (c *Challenge) Challenge2(input io.Reader) (string, error) {
+	return c.Challenge2Context(context.Background(), input)
+}
+
+func (c *Challenge) Challenge1Context(ctx context.Context, input io.Reader) (string, error) {
+	return answer(c.run(ctx, 1, input))
+}
+
+func (c *Challenge) Challenge2Context(ctx context.Context, input io.Reader) (string, error) {
+	return answer(c.run(ctx, 2, input))
+}
+
+func (c *Challenge) Challenge1Result(ctx context.Context, input io.Reader) (*challenge.Result, error) {
+	return c.run(ctx, 1, input)
+}
+
+func (c *Challenge) Challenge2Result(ctx context.Context, input io.Reader) (*challenge.Result, error) {
+	return c.run(ctx, 2, input)
+}
+
+func answer(result *challenge.Result, err error) (string, error) {
+	if err != nil {
+		return "", err
+	}
+	return result.Answer, nil
+}
+
+// response is what a plugin writes to stdout
+type response struct {
+	Answer    interface{}          `json:"answer"`
+	Error     string               `json:"error"`
+	Artifacts []challenge.Artifact `json:"artifacts"`
+}
+
+// run runs the plugin to solve a part, killing it if ctx is done or it runs out of time
+func (c *Challenge) run(ctx context.Context, part int, input io.Reader) (*challenge.Result, error) {
+	runCtx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	p := c.plugin
+	cmd := exec.Command(p.Command[0], p.Command[1:]...)
+	group(cmd)
+	cmd.Dir = p.Dir
+	cmd.Env = append(os.Environ(),
+		fmt.Sprintf("AOC_YEAR=%d", p.Year),
+		fmt.Sprintf("AOC_DAY=%d", p.Day),
+		fmt.Sprintf("AOC_PART=%d", part),
+	)
+	cmd.Stdin = input
+	var stdout bytes.Buffer
+	stderr := &tail{max: maxStderr}
+	cmd.Stdout, cmd.Stderr = &stdout, stderr
+
+	err := cmd.Start()
+	if err == nil {
+		// killing only the command would leave anything it started holding stdout open,
+		// and Wait waiting for them
+		stopped := make(chan struct{})
+		go func() {
+			select {
+			case <-runCtx.Done():
+				kill(cmd)
+			case <-stopped:
+			}
+		}()
+		err = cmd.Wait()
+		close(stopped)
+	}
+
+	switch {
+	case ctx.Err() != nil:
+		return nil, challenge.Aborted(ctx)
+	case runCtx.Err() != nil:
+		err = fmt.Errorf("timed out after %s", c.timeout)
+	default:
+		// a plugin reporting its own error may exit with an error too
+		result, decodeErr := decode(stdout.Bytes())
+		var failed failure
+		switch {
+		case errors.As(decodeErr, &failed):
+			return nil, failed
+		case err == nil && decodeErr == nil:
+			return result, nil
+		case err == nil:
+			err = decodeErr
+		}
+	}
+
+	return nil, &Error{
+		Command: strings.Join(p.Command, " "),
+		Err:     err,
+		Stderr:  strings.TrimSpace(string(stderr.b)),
+	}
+}
+
+// failure is an error reported by the plugin itself
+type failure string
+
+func (f failure) Error() string {
+	return string(f)
+}
+
+// decode reads the response a plugin wrote to stdout
+func decode(data []byte) (*challenge.Result, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var resp response
+	if err := dec.Decode(&resp); err != nil {
+		return nil, fmt.Errorf("invalid response %q: %w", abbreviate(data), err)
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, fmt.Errorf("invalid response %q: more than one JSON value", abbreviate(data))
+	}
+
+	if resp.Error != "" {
+		return nil, failure(resp.Error)
+	}
+
+	result := &challenge.Result{Artifacts: resp.Artifacts}
+	switch a := resp.Answer.(type) {
+	case string:
+		result.Answer = a
+	case json.Number:
+		result.Answer = a.String()
+	default:
+		return nil, fmt.Errorf("invalid response %q: want a string or number answer, or an error", abbreviate(data))
+	}
+	if result.Answer == "" {
+		return nil, fmt.Errorf("invalid response %q: empty answer", abbreviate(data))
+	}
+
+	return result, nil
+}
+
+// abbreviate shortens a response to quote in an error
+func abbreviate(data []byte) string {
+	const max = 80
+	s := strings.TrimSpace(string(data))
+	if len(s) > max {
+		s = s[:max] + "..."
+	}
+	return s
+}
+
+// tail keeps the last max bytes written to it
+type tail struct {
+	max int
+	b   []byte
+}
+
+func (t *tail) Write(p []byte) (int, error) {
+	t.b = append(t.b, p...)
+	if len(t.b) > t.max {
+		t.b = t.b[len(t.b)-t.max:]
+	}
+	return len(p), nil
+}
@@ -0,0 +1,222 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ryderlewis/aoc2021/pkg/challenge"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// year holds the plugins registered below, so the real challenges needn't be
+const year = 1999
+
+// helperEnv makes the test binary act as a plugin, behaving as its value says
+const helperEnv = "PLUGIN_TEST_HELPER"
+
+func TestMain(m *testing.M) {
+	if mode := os.Getenv(helperEnv); mode != "" {
+		os.Exit(helper(mode))
+	}
+	os.Exit(m.Run())
+}
+
+// helper is a plugin that sums the numbers in its input, multiplying the sum by the
+// part, unless mode asks it to misbehave
+func helper(mode string) int {
+	input, _ := io.ReadAll(os.Stdin)
+	sum := 0
+	for _, field := range strings.Fields(string(input)) {
+		n, err := strconv.Atoi(field)
+		if err != nil {
+			fmt.Printf(`{"error": %q}`, "Invalid number "+strconv.Quote(field))
+			return 1
+		}
+		sum += n
+	}
+	part, _ := strconv.Atoi(os.Getenv("AOC_PART"))
+
+	switch mode {
+	case "sum":
+		fmt.Printf(`{"answer": %d, "artifacts": [{"kind": "stat", "name": "Day", "value": %q}]}`, sum*part, os.Getenv("AOC_DAY"))
+	case "crash":
+		fmt.Fprintln(os.Stderr, "panic: something broke")
+		return 2
+	case "garbage":
+		fmt.Println("the answer is 6")
+	case "sleep":
+		time.Sleep(10 * time.Second)
+	case "fork", "orphan":
+		// start a sleeping child sharing stdout, as shell wrappers do
+		child := exec.Command(os.Args[0])
+		child.Env = append(os.Environ(), helperEnv+"=sleep")
+		child.Stdout = os.Stdout
+		if err := child.Start(); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		if mode == "fork" {
+			child.Wait()
+		}
+	}
+	return 0
+}
+
+// helperPlugin returns a plugin running the test binary in mode
+func helperPlugin(t *testing.T, mode string) Plugin {
+	t.Setenv(helperEnv, mode)
+	return Plugin{Year: year, Day: 1, Command: []string{os.Args[0]}, Timeout: "5s"}
+}
+
+func solve(t *testing.T, p Plugin, part int, input string) (*challenge.Result, error) {
+	t.Helper()
+
+	c, err := New(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return challenge.Solve(context.Background(), c, part, strings.NewReader(input))
+}
+
+func TestAnswer(t *testing.T) {
+	result, err := solve(t, helperPlugin(t, "sum"), 2, "1\n2\n3\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Answer != "12" {
+		t.Errorf("got %q, want %q", result.Answer, "12")
+	}
+	if len(result.Artifacts) != 1 || result.Artifacts[0] != (challenge.Artifact{Kind: challenge.StatArtifact, Name: "Day", Value: "1"}) {
+		t.Errorf("got artifacts %+v", result.Artifacts)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	tests := []struct {
+		mode, input string
+		want        string
+	}{
+		{"sum", "1\nx\n", `Invalid number "x"`},
+		{"crash", "1\n", "exit status 2: panic: something broke"},
+		{"garbage", "1\n", `invalid response "the answer is 6"`},
+		{"silent", "1\n", `invalid response ""`},
+	}
+	for _, test := range tests {
+		_, err := solve(t, helperPlugin(t, test.mode), 1, test.input)
+		if err == nil || !strings.Contains(err.Error(), test.want) {
+			t.Errorf("%s: got %v, want an error containing %q", test.mode, err, test.want)
+		}
+	}
+
+	var perr *Error
+	if _, err := solve(t, helperPlugin(t, "crash"), 1, ""); !errors.As(err, &perr) || perr.Stderr != "panic: something broke" {
+		t.Errorf("got %v, want the plugin's stderr", err)
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	p := helperPlugin(t, "sleep")
+	p.Timeout = "50ms"
+	if _, err := solve(t, p, 1, ""); err == nil || !strings.Contains(err.Error(), "timed out after 50ms") {
+		t.Errorf("got %v, want a timeout", err)
+	}
+
+	// the caller giving up stops the plugin too
+	c, err := New(helperPlugin(t, "sleep"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := challenge.Solve(ctx, c, 1, strings.NewReader("")); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("got %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("took %s to give up", elapsed)
+	}
+}
+
+func TestTimeoutChildren(t *testing.T) {
+	for _, mode := range []string{"fork", "orphan"} {
+		p := helperPlugin(t, mode)
+		p.Timeout = "200ms"
+
+		start := time.Now()
+		_, err := solve(t, p, 1, "")
+		if err == nil || !strings.Contains(err.Error(), "timed out after 200ms") {
+			t.Errorf("%s: got %v, want a timeout", mode, err)
+		}
+		if elapsed := time.Since(start); elapsed > 2*time.Second {
+			t.Errorf("%s: took %s to time out, waiting for the child process", mode, elapsed)
+		}
+	}
+}
+
+// registerRuns counts the runs of TestRegister, each of which needs a year of its own as
+// nothing can be unregistered
+var registerRuns int
+
+func TestRegister(t *testing.T) {
+	registerRuns++
+	year := year + registerRuns
+
+	dir := t.TempDir()
+	command, err := filepath.Abs(os.Args[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := filepath.Join(dir, DefaultConfig)
+	data := fmt.Sprintf(`{"plugins": [{"year": %d, "day": 3, "command": [%q], "dir": "sub"}]}`, year, command)
+	if err := os.WriteFile(config, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := cfg.Plugins[0].Dir; got != filepath.Join(dir, "sub") {
+		t.Errorf("got dir %s", got)
+	}
+
+	cfg.Plugins[0].Dir = ""
+	if err := cfg.Register(); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(helperEnv, "sum")
+	dc, err := challenge.Lookup(year, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if answer, err := dc.Challenge1(strings.NewReader("4 5")); err != nil || answer != "9" {
+		t.Errorf("got %q, %v", answer, err)
+	}
+
+	if err := cfg.Register(); err == nil {
+		t.Error("registered the same day twice")
+	}
+	if err := RegisterFile(filepath.Join(dir, "missing.json")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("got %v, want os.ErrNotExist", err)
+	}
+}
+
+func TestInvalid(t *testing.T) {
+	for _, p := range []Plugin{
+		{Year: year, Day: 26, Command: []string{"true"}},
+		{Year: year, Day: 1},
+		{Year: year, Day: 1, Command: []string{"true"}, Timeout: "soon"},
+	} {
+		if _, err := New(p); err == nil {
+			t.Errorf("New(%+v): got no error", p)
+		}
+	}
+}
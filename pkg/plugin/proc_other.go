@@ -0,0 +1,16 @@
+//go:build !linux && !darwin && !dragonfly && !freebsd && !netbsd && !openbsd
+// +build !linux,!darwin,!dragonfly,!freebsd,!netbsd,!openbsd
+
+package plugin
+
+import (
+	"os/exec"
+)
+
+// group does nothing, as process groups can't be made here
+func group(cmd *exec.Cmd) {}
+
+// kill kills a started cmd, though not the processes it started
+func kill(cmd *exec.Cmd) error {
+	return cmd.Process.Kill()
+}
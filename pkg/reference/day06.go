@@ -0,0 +1,25 @@
+package reference
+
+import (
+	"github.com/ryderlewis/aoc2021/pkg/y2021/day06"
+)
+
+// Lanternfish counts the fish after the given number of days by keeping every fish's
+// timer in a list
+func Lanternfish(p *day06.Puzzle, days int) int {
+	fish := make([]int, len(p.Timers))
+	copy(fish, p.Timers)
+
+	for day := 0; day < days; day++ {
+		for i, n := 0, len(fish); i < n; i++ {
+			if fish[i] == 0 {
+				fish[i] = 6
+				fish = append(fish, 8)
+			} else {
+				fish[i]--
+			}
+		}
+	}
+
+	return len(fish)
+}
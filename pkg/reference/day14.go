@@ -0,0 +1,37 @@
+package reference
+
+import (
+	"github.com/ryderlewis/aoc2021/pkg/y2021/day14"
+	"sort"
+)
+
+// Polymerize runs steps of pair insertion on the polymer as a string, and returns the
+// difference between the most and least common elements
+func Polymerize(p *day14.Puzzle, steps int) int {
+	polymer := []rune(p.Template)
+
+	for step := 0; step < steps; step++ {
+		next := make([]rune, 0, 2*len(polymer))
+		for i, c := range polymer {
+			next = append(next, c)
+			if i+1 < len(polymer) {
+				if insert, ok := p.Rules[string([]rune{c, polymer[i+1]})]; ok {
+					next = append(next, insert)
+				}
+			}
+		}
+		polymer = next
+	}
+
+	counts := make(map[rune]int)
+	for _, c := range polymer {
+		counts[c]++
+	}
+	vals := make([]int, 0, len(counts))
+	for _, count := range counts {
+		vals = append(vals, count)
+	}
+	sort.Ints(vals)
+
+	return vals[len(vals)-1] - vals[0]
+}
@@ -0,0 +1,34 @@
+package reference
+
+import (
+	"github.com/ryderlewis/aoc2021/pkg/y2021/day21"
+)
+
+// DiracWins plays every universe of the dirac die game one roll at a time until a player
+// reaches the target score, and counts the universes the more successful player wins in
+func DiracWins(p *day21.Puzzle, target int) uint64 {
+	var wins [2]uint64
+
+	var play func(pos, score [2]int, player, roll, moved int)
+	play = func(pos, score [2]int, player, roll, moved int) {
+		if roll == 3 {
+			pos[player] = (pos[player] + moved) % 10
+			score[player] += pos[player] + 1
+			if score[player] >= target {
+				wins[player]++
+				return
+			}
+			player, roll, moved = 1-player, 0, 0
+		}
+
+		for die := 1; die <= 3; die++ {
+			play(pos, score, player, roll+1, moved+die)
+		}
+	}
+	play(p.Start, [2]int{}, 0, 0, 0)
+
+	if wins[1] > wins[0] {
+		return wins[1]
+	}
+	return wins[0]
+}
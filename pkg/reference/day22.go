@@ -0,0 +1,44 @@
+package reference
+
+import (
+	"github.com/ryderlewis/aoc2021/pkg/y2021/day22"
+)
+
+// Reboot runs the reboot steps on every cube from lo to hi in each dimension, one cube at
+// a time, and counts the cubes left on. Cubes outside the bounds are ignored.
+func Reboot(p *day22.Puzzle, lo, hi int) int {
+	size := hi - lo + 1
+	cubes := make([]bool, size*size*size)
+
+	clip := func(min, max int) (int, int) {
+		if min < lo {
+			min = lo
+		}
+		if max > hi {
+			max = hi
+		}
+		return min - lo, max - lo
+	}
+
+	for _, step := range p.Steps {
+		minX, maxX := clip(step.MinX, step.MaxX)
+		minY, maxY := clip(step.MinY, step.MaxY)
+		minZ, maxZ := clip(step.MinZ, step.MaxZ)
+		for x := minX; x <= maxX; x++ {
+			for y := minY; y <= maxY; y++ {
+				for z := minZ; z <= maxZ; z++ {
+					cubes[(x*size+y)*size+z] = step.On
+				}
+			}
+		}
+	}
+
+	count := 0
+	for _, on := range cubes {
+		if on {
+			count++
+		}
+	}
+
+	return count
+}
@@ -0,0 +1,112 @@
+package reference
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Case pairs a real solution with its reference, both solving the same puzzle input
+type Case struct {
+	Name      string
+	Generate  func(seed int64) (string, error) // a random small input
+	Solve     func(input string) (string, error)
+	Reference func(input string) (string, error)
+
+	// Sep splits inputs into the pieces removed while minimizing. Defaults to lines.
+	Sep string
+}
+
+// Divergence is an input the real solution and its reference disagree on
+type Divergence struct {
+	Case  string
+	Seed  int64
+	Input string // minimized input
+	Got   string // the real solution's answer or error
+	Want  string // the reference's answer or error
+}
+
+func (d *Divergence) Error() string {
+	return fmt.Sprintf("%s: seed %d: got %s, want %s, on input:\n%s", d.Case, d.Seed, d.Got, d.Want, d.Input)
+}
+
+// Check runs the case against an input generated from each seed, and returns the first
+// divergence, minimized, or nil if there is none
+func Check(c Case, seeds []int64) (*Divergence, error) {
+	for _, seed := range seeds {
+		input, err := c.Generate(seed)
+		if err != nil {
+			return nil, fmt.Errorf("%s: seed %d: %w", c.Name, seed, err)
+		}
+
+		if _, _, ok := c.compare(input); ok {
+			continue
+		}
+
+		input = Minimize(input, c.sep(), func(candidate string) bool {
+			_, _, ok := c.compare(candidate)
+			return !ok
+		})
+		got, want, _ := c.compare(input)
+
+		return &Divergence{Case: c.Name, Seed: seed, Input: input, Got: got, Want: want}, nil
+	}
+
+	return nil, nil
+}
+
+// compare solves input both ways, and reports whether they agree. Inputs that both
+// reject agree, so minimizing never settles on an input that is merely malformed.
+func (c Case) compare(input string) (string, string, bool) {
+	got, gotErr := c.Solve(input)
+	want, wantErr := c.Reference(input)
+
+	switch {
+	case gotErr != nil && wantErr != nil:
+		return "error: " + gotErr.Error(), "error: " + wantErr.Error(), true
+	case gotErr != nil:
+		return "error: " + gotErr.Error(), want, false
+	case wantErr != nil:
+		return got, "error: " + wantErr.Error(), false
+	default:
+		return got, want, got == want
+	}
+}
+
+func (c Case) sep() string {
+	if c.Sep == "" {
+		return "\n"
+	}
+	return c.Sep
+}
+
+// Minimize shrinks input by removing sep separated pieces of it, largest chunks first,
+// for as long as it still fails. The result fails, but removing any one piece from it
+// would not.
+func Minimize(input, sep string, fails func(input string) bool) string {
+	trailing := strings.HasSuffix(input, sep)
+	pieces := strings.Split(strings.TrimSuffix(input, sep), sep)
+	join := func(pieces []string) string {
+		s := strings.Join(pieces, sep)
+		if trailing {
+			s += sep
+		}
+		return s
+	}
+
+	for chunk := len(pieces) / 2; chunk >= 1; chunk /= 2 {
+		for removed := true; removed; {
+			removed = false
+			for start := 0; start+chunk <= len(pieces) && len(pieces) > 1; {
+				candidate := append(append([]string{}, pieces[:start]...), pieces[start+chunk:]...)
+				if fails(join(candidate)) {
+					pieces = candidate
+					removed = true
+				} else {
+					start += chunk
+				}
+			}
+		}
+	}
+
+	return join(pieces)
+}
@@ -0,0 +1,4 @@
+// Package reference holds slow, obviously correct solutions for the days whose real
+// solutions rely on clever formulations. They are only practical on small inputs, and
+// exist to be checked against the real solutions by the differential tests.
+package reference
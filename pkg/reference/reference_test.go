@@ -0,0 +1,266 @@
+package reference
+
+import (
+	"context"
+	"github.com/ryderlewis/aoc2021/pkg/challenge"
+	"github.com/ryderlewis/aoc2021/pkg/gen"
+	"github.com/ryderlewis/aoc2021/pkg/inputs"
+	"github.com/ryderlewis/aoc2021/pkg/y2021/day06"
+	"github.com/ryderlewis/aoc2021/pkg/y2021/day14"
+	"github.com/ryderlewis/aoc2021/pkg/y2021/day21"
+	"github.com/ryderlewis/aoc2021/pkg/y2021/day22"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// cases check every clever formulation against its reference, on inputs small enough
+// for the reference to solve
+var cases = []Case{
+	{
+		Name:     "day06 part 1",
+		Generate: generate(6, 5),
+		Solve:    runner(6, 1),
+		Reference: func(input string) (string, error) {
+			p, err := day06.Parse(strings.NewReader(input))
+			if err != nil {
+				return "", err
+			}
+			return strconv.Itoa(Lanternfish(p, 80)), nil
+		},
+		Sep: ",",
+	},
+	{
+		Name:     "day06 count after 120 days",
+		Generate: generate(6, 5),
+		Solve: func(input string) (string, error) {
+			p, err := day06.Parse(strings.NewReader(input))
+			if err != nil {
+				return "", err
+			}
+			return strconv.Itoa(day06.Count(p.Timers, 120)), nil
+		},
+		Reference: func(input string) (string, error) {
+			p, err := day06.Parse(strings.NewReader(input))
+			if err != nil {
+				return "", err
+			}
+			return strconv.Itoa(Lanternfish(p, 120)), nil
+		},
+		Sep: ",",
+	},
+	{
+		Name:      "day14 part 1",
+		Generate:  generate(14, 4),
+		Solve:     runner(14, 1),
+		Reference: polymerize(10),
+	},
+	{
+		Name:     "day14 pair counts after 15 steps",
+		Generate: generate(14, 4),
+		Solve: func(input string) (string, error) {
+			p, err := day14.Parse(strings.NewReader(input))
+			if err != nil {
+				return "", err
+			}
+			return strconv.Itoa(day14.Polymerize(p, 15)), nil
+		},
+		Reference: polymerize(15),
+	},
+	{
+		Name:     "day21 universes to a low score",
+		Generate: generate(21, 0),
+		Solve: func(input string) (string, error) {
+			p, err := day21.Parse(strings.NewReader(input))
+			if err != nil {
+				return "", err
+			}
+			return strconv.FormatUint(day21.DiracWins(p, 8), 10), nil
+		},
+		Reference: func(input string) (string, error) {
+			p, err := day21.Parse(strings.NewReader(input))
+			if err != nil {
+				return "", err
+			}
+			return strconv.FormatUint(DiracWins(p, 8), 10), nil
+		},
+	},
+	{
+		Name:      "day22 part 1",
+		Generate:  generate(22, 24),
+		Solve:     runner(22, 1),
+		Reference: reboot,
+	},
+	{
+		// the first twenty steps are all within the initialization region, so every cube
+		// fits in the voxels
+		Name:      "day22 part 2",
+		Generate:  generate(22, 12),
+		Solve:     runner(22, 2),
+		Reference: reboot,
+	},
+}
+
+func TestDifferential(t *testing.T) {
+	seeds := make([]int64, 20)
+	if testing.Short() {
+		seeds = seeds[:3]
+	}
+	for i := range seeds {
+		seeds[i] = int64(i + 1)
+	}
+
+	for _, c := range cases {
+		d, err := Check(c, seeds)
+		if err != nil {
+			t.Errorf("%v", err)
+		} else if d != nil {
+			t.Errorf("%v", d)
+		}
+	}
+}
+
+// TestReferenceExamples checks the references against the known answers to the examples
+func TestReferenceExamples(t *testing.T) {
+	tests := []struct {
+		day   int
+		solve func(input string) (string, error)
+		part  int
+	}{
+		{6, cases[0].Reference, 1},
+		{14, polymerize(10), 1},
+		{22, reboot, 1},
+	}
+
+	answers, err := inputs.LoadAnswers(filepath.Join("..", "..", inputs.DefaultDir), 2021)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range tests {
+		data, err := os.ReadFile(inputs.Path(filepath.Join("..", "..", inputs.DefaultDir), 2021, test.day, true))
+		if err != nil {
+			t.Fatal(err)
+		}
+		want, _ := answers.Lookup(test.day, test.part, inputs.Name(test.day, true))
+
+		got, err := test.solve(string(data))
+		if err != nil {
+			t.Errorf("day %d: unexpected error: %v", test.day, err)
+		} else if got != want {
+			t.Errorf("day %d: got %s, want %s", test.day, got, want)
+		}
+	}
+}
+
+// TestCountLonger counts lanternfish for longer than either part does, too long for the
+// reference, by keeping a count of the fish with each timer instead
+func TestCountLonger(t *testing.T) {
+	timers := []int{3, 4, 3, 1, 2}
+	const days = 300
+
+	counts := make([]int, 9)
+	for _, timer := range timers {
+		counts[timer]++
+	}
+	for day := 0; day < days; day++ {
+		spawning := counts[0]
+		copy(counts, counts[1:])
+		counts[6] += spawning
+		counts[8] = spawning
+	}
+	want := 0
+	for _, n := range counts {
+		want += n
+	}
+
+	if got := day06.Count(timers, days); got != want {
+		t.Errorf("got %d, want %d", got, want)
+	}
+}
+
+func TestMinimize(t *testing.T) {
+	// fails as long as both a and c are left
+	fails := func(input string) bool {
+		return strings.Contains(input, "a") && strings.Contains(input, "c")
+	}
+
+	if got := Minimize("a\nb\nc\nd\ne\n", "\n", fails); got != "a\nc\n" {
+		t.Errorf("got %q, want %q", got, "a\nc\n")
+	}
+	if got := Minimize("e,d,c,b,a", ",", fails); got != "c,a" {
+		t.Errorf("got %q, want %q", got, "c,a")
+	}
+}
+
+func TestCheckReportsMinimized(t *testing.T) {
+	// a deliberately wrong solution, which miscounts any school with a fish at 3
+	c := cases[0]
+	c.Solve = func(input string) (string, error) {
+		answer, err := c.Reference(input)
+		if strings.Contains(input, "3") {
+			answer += "0"
+		}
+		return answer, err
+	}
+	c.Generate = func(seed int64) (string, error) {
+		return "1,2,3,4,5,3,1\n", nil
+	}
+
+	d, err := Check(c, []int64{1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d == nil {
+		t.Fatal("got no divergence")
+	}
+	if d.Input != "3" {
+		t.Errorf("got minimized input %q, want %q", d.Input, "3")
+	}
+}
+
+// generate returns a generator of the day's inputs at the given scale
+func generate(day, scale int) func(seed int64) (string, error) {
+	return func(seed int64) (string, error) {
+		input, err := gen.Generate(day, gen.Options{Seed: seed, Scale: scale})
+		if err != nil {
+			return "", err
+		}
+		return string(input.Text), nil
+	}
+}
+
+// runner returns a solver using the day's registered challenge
+func runner(day, part int) func(input string) (string, error) {
+	return func(input string) (string, error) {
+		dc, err := challenge.Lookup(2021, day)
+		if err != nil {
+			return "", err
+		}
+		result, err := challenge.Solve(context.Background(), dc, part, strings.NewReader(input))
+		if err != nil {
+			return "", err
+		}
+		return result.Answer, nil
+	}
+}
+
+func polymerize(steps int) func(input string) (string, error) {
+	return func(input string) (string, error) {
+		p, err := day14.Parse(strings.NewReader(input))
+		if err != nil {
+			return "", err
+		}
+		return strconv.Itoa(Polymerize(p, steps)), nil
+	}
+}
+
+func reboot(input string) (string, error) {
+	p, err := day22.Parse(strings.NewReader(input))
+	if err != nil {
+		return "", err
+	}
+	return strconv.Itoa(Reboot(p, -50, 50)), nil
+}
@@ -0,0 +1,166 @@
+// Package search finds least cost paths through graphs that are only known implicitly,
+// by the neighbors of each state, so that huge state spaces are explored only as far as
+// they need to be.
+package search
+
+import (
+	"container/heap"
+	"context"
+	"errors"
+)
+
+// ErrNoPath is returned when no goal can be reached from the start
+var ErrNoPath = errors.New("no path to a goal")
+
+// State is a node in the graph. States are used as map keys, so they must be comparable,
+// and equal states are the same node.
+type State interface{}
+
+// Edge is a step from one state to another, and what it costs
+type Edge struct {
+	To   State
+	Cost int
+}
+
+// Graph is a state space to search
+type Graph interface {
+	// Neighbors returns the steps that can be taken from s. Costs must not be negative.
+	Neighbors(s State) []Edge
+
+	// Goal reports whether s is one of the states being searched for
+	Goal(s State) bool
+}
+
+// Heuristic estimates the least cost from s to a goal. A* only finds least cost paths
+// with heuristics that never overestimate.
+type Heuristic func(s State) int
+
+// Result is the least cost path found to a goal
+type Result struct {
+	Cost     int
+	Path     []State // from the start to the goal, including both
+	Expanded int     // how many states were explored on the way
+}
+
+// checkEvery is how many states are expanded between checks for cancellation
+const checkEvery = 1024
+
+// Dijkstra finds the least cost path from start to a goal, giving up with ctx's error
+// once ctx is done
+func Dijkstra(ctx context.Context, g Graph, start State) (*Result, error) {
+	return AStar(ctx, g, start, nil)
+}
+
+// AStar finds the least cost path from start to a goal, exploring the states that h
+// estimates are closest to a goal first. A nil h explores in order of cost alone, as
+// Dijkstra does. It gives up with ctx's error once ctx is done.
+func AStar(ctx context.Context, g Graph, start State, h Heuristic) (*Result, error) {
+	if h == nil {
+		h = func(State) int { return 0 }
+	}
+
+	costs := map[State]int{start: 0}
+	prev := make(map[State]State)
+	q := &queue{}
+	heap.Push(q, &item{state: start, priority: h(start)})
+
+	for expanded := 0; q.Len() > 0; {
+		cur := heap.Pop(q).(*item)
+		if cur.cost > costs[cur.state] {
+			// a cheaper way here has been found since this was queued
+			continue
+		}
+
+		if g.Goal(cur.state) {
+			return &Result{Cost: cur.cost, Path: Path(prev, start, cur.state), Expanded: expanded}, nil
+		}
+
+		if expanded++; expanded%checkEvery == 0 && ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		for _, e := range g.Neighbors(cur.state) {
+			cost := cur.cost + e.Cost
+			if best, ok := costs[e.To]; ok && best <= cost {
+				continue
+			}
+			costs[e.To] = cost
+			prev[e.To] = cur.state
+			heap.Push(q, &item{state: e.To, cost: cost, priority: cost + h(e.To)})
+		}
+	}
+
+	return nil, ErrNoPath
+}
+
+// BFS finds the path from start to a goal with the fewest steps, ignoring their costs.
+// The result's cost is the number of steps. It gives up with ctx's error once ctx is done.
+func BFS(ctx context.Context, g Graph, start State) (*Result, error) {
+	prev := make(map[State]State)
+	seen := map[State]bool{start: true}
+	steps := map[State]int{start: 0}
+
+	for q, expanded := []State{start}, 0; len(q) > 0; {
+		cur := q[0]
+		q = q[1:]
+
+		if g.Goal(cur) {
+			return &Result{Cost: steps[cur], Path: Path(prev, start, cur), Expanded: expanded}, nil
+		}
+
+		if expanded++; expanded%checkEvery == 0 && ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		for _, e := range g.Neighbors(cur) {
+			if seen[e.To] {
+				continue
+			}
+			seen[e.To] = true
+			prev[e.To] = cur
+			steps[e.To] = steps[cur] + 1
+			q = append(q, e.To)
+		}
+	}
+
+	return nil, ErrNoPath
+}
+
+// Path follows prev, which maps each state to the one it was reached from, back from
+// end to start, and returns the states in order from start to end
+func Path(prev map[State]State, start, end State) []State {
+	path := []State{end}
+	for s := end; s != start; {
+		s = prev[s]
+		path = append(path, s)
+	}
+
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+
+	return path
+}
+
+// item is a state waiting in the queue, with the cost of reaching it
+type item struct {
+	state    State
+	cost     int
+	priority int
+}
+
+// queue is a min-heap of items by priority
+type queue []*item
+
+func (q queue) Len() int            { return len(q) }
+func (q queue) Less(i, j int) bool  { return q[i].priority < q[j].priority }
+func (q queue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *queue) Push(x interface{}) { *q = append(*q, x.(*item)) }
+
+func (q *queue) Pop() interface{} {
+	old := *q
+	it := old[len(old)-1]
+	*q = old[:len(old)-1]
+
+	return it
+}
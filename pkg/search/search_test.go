@@ -0,0 +1,137 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// graph is a small weighted graph, given as the edges out of each state
+type graph struct {
+	edges map[string][]Edge
+	goal  string
+}
+
+func (g *graph) Neighbors(s State) []Edge {
+	return g.edges[s.(string)]
+}
+
+func (g *graph) Goal(s State) bool {
+	return s.(string) == g.goal
+}
+
+// a -> d directly is one step but costs the most; a -> b -> c -> d is cheapest
+var small = &graph{
+	edges: map[string][]Edge{
+		"a": {{"d", 10}, {"b", 1}, {"e", 2}},
+		"b": {{"c", 1}},
+		"c": {{"d", 1}, {"a", 1}},
+		"e": {{"d", 2}},
+	},
+	goal: "d",
+}
+
+func TestDijkstra(t *testing.T) {
+	r, err := Dijkstra(context.Background(), small, "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Cost != 3 {
+		t.Errorf("got cost %d, want 3", r.Cost)
+	}
+	if want := []State{"a", "b", "c", "d"}; !reflect.DeepEqual(r.Path, want) {
+		t.Errorf("got path %v, want %v", r.Path, want)
+	}
+}
+
+func TestBFS(t *testing.T) {
+	r, err := BFS(context.Background(), small, "a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Cost != 1 {
+		t.Errorf("got %d steps, want 1", r.Cost)
+	}
+	if want := []State{"a", "d"}; !reflect.DeepEqual(r.Path, want) {
+		t.Errorf("got path %v, want %v", r.Path, want)
+	}
+}
+
+func TestNoPath(t *testing.T) {
+	g := &graph{edges: small.edges, goal: "z"}
+	if _, err := Dijkstra(context.Background(), g, "a"); !errors.Is(err, ErrNoPath) {
+		t.Errorf("Dijkstra: got %v, want ErrNoPath", err)
+	}
+	if _, err := BFS(context.Background(), g, "a"); !errors.Is(err, ErrNoPath) {
+		t.Errorf("BFS: got %v, want ErrNoPath", err)
+	}
+}
+
+// line is an endless line of states, where stepping right costs 1 and left costs 3
+type line struct {
+	goal int
+}
+
+func (l line) Neighbors(s State) []Edge {
+	i := s.(int)
+	return []Edge{{i + 1, 1}, {i - 1, 3}}
+}
+
+func (l line) Goal(s State) bool {
+	return s.(int) == l.goal
+}
+
+func TestAStar(t *testing.T) {
+	g := line{goal: -40}
+	distance := func(s State) int {
+		d := s.(int) - g.goal
+		if d < 0 {
+			return -d
+		}
+		return 3 * d
+	}
+
+	plain, err := Dijkstra(context.Background(), g, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	guided, err := AStar(context.Background(), g, 0, distance)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if plain.Cost != 120 || guided.Cost != 120 {
+		t.Errorf("got costs %d and %d, want 120", plain.Cost, guided.Cost)
+	}
+	if len(guided.Path) != 41 {
+		t.Errorf("got a path of %d states, want 41", len(guided.Path))
+	}
+	if guided.Expanded >= plain.Expanded {
+		t.Errorf("A* expanded %d states, no fewer than Dijkstra's %d", guided.Expanded, plain.Expanded)
+	}
+}
+
+func TestCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// the goal can't be reached, so only cancellation stops the search
+	g := line{goal: 1 << 62}
+	if _, err := Dijkstra(ctx, g, 0); !errors.Is(err, context.Canceled) {
+		t.Errorf("Dijkstra: got %v, want context.Canceled", err)
+	}
+	if _, err := BFS(ctx, g, 0); !errors.Is(err, context.Canceled) {
+		t.Errorf("BFS: got %v, want context.Canceled", err)
+	}
+}
+
+func TestPath(t *testing.T) {
+	prev := map[State]State{2: 1, 3: 2, 4: 2}
+	if got, want := Path(prev, 1, 3), []State{1, 2, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got, want := Path(prev, 1, 1), []State{1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
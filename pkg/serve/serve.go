@@ -0,0 +1,211 @@
+// Package serve exposes the registered challenges over HTTP as a JSON API, so they can
+// be solved without running the command line tool.
+//
+//	GET  /v1/days                        lists the days that can be solved
+//	POST /v1/days/{day}/parts/{part}     solves a part, with the puzzle input as the body
+package serve
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/ryderlewis/aoc2021/pkg/challenge"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultMaxInput    = 1 << 20 // bytes
+	DefaultTimeout     = 30 * time.Second
+	DefaultConcurrency = 4
+)
+
+// Options control what the server will take on
+type Options struct {
+	Year        int           // the year whose challenges are served
+	MaxInput    int64         // the largest puzzle input accepted, in bytes
+	Timeout     time.Duration // how long each solve may take
+	Concurrency int           // the most solves running at once. Requests beyond it are turned away.
+}
+
+// Server handles requests to the API
+type Server struct {
+	opts  Options
+	slots chan struct{} // holds a value for each solve running
+}
+
+// Day describes a day that can be solved
+type Day struct {
+	Day   int   `json:"day"`
+	Parts []int `json:"parts"`
+}
+
+// Listing is the response listing the days
+type Listing struct {
+	Year int   `json:"year"`
+	Days []Day `json:"days"`
+}
+
+// Solution is the response to a solve. Error is set instead of Answer if it failed.
+type Solution struct {
+	Day        int                  `json:"day"`
+	Part       int                  `json:"part"`
+	Answer     string               `json:"answer,omitempty"`
+	Error      string               `json:"error,omitempty"`
+	DurationNS int64                `json:"duration_ns"`
+	Artifacts  []challenge.Artifact `json:"artifacts,omitempty"`
+}
+
+// failure is the response to a request that couldn't be handled
+type failure struct {
+	Error string `json:"error"`
+}
+
+// New returns a server with opts, filling in defaults for any left zero
+func New(opts Options) *Server {
+	if opts.MaxInput <= 0 {
+		opts.MaxInput = DefaultMaxInput
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultTimeout
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = DefaultConcurrency
+	}
+
+	return &Server{opts: opts, slots: make(chan struct{}, opts.Concurrency)}
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+	switch {
+	case len(path) == 2 && path[0] == "v1" && path[1] == "days":
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			notAllowed(w, http.MethodGet)
+			return
+		}
+		s.list(w)
+	case len(path) == 5 && path[0] == "v1" && path[1] == "days" && path[3] == "parts":
+		if r.Method != http.MethodPost {
+			notAllowed(w, http.MethodPost)
+			return
+		}
+		day, err := strconv.Atoi(path[2])
+		if err != nil || day < 1 || day > 25 {
+			writeError(w, http.StatusNotFound, fmt.Errorf("no such day %q", path[2]))
+			return
+		}
+		part, err := strconv.Atoi(path[4])
+		if err != nil || part < 1 || part > 2 {
+			writeError(w, http.StatusNotFound, fmt.Errorf("no such part %q", path[4]))
+			return
+		}
+		s.solve(w, r, day, part)
+	default:
+		writeError(w, http.StatusNotFound, fmt.Errorf("no such endpoint %s", r.URL.Path))
+	}
+}
+
+// list writes the days that can be solved
+func (s *Server) list(w http.ResponseWriter) {
+	listing := Listing{Year: s.opts.Year, Days: make([]Day, 0)}
+	for _, day := range challenge.Days(s.opts.Year) {
+		listing.Days = append(listing.Days, Day{Day: day, Parts: []int{1, 2}})
+	}
+
+	writeJSON(w, http.StatusOK, listing)
+}
+
+// solve solves a part of a day against the request body, and writes the solution
+func (s *Server) solve(w http.ResponseWriter, r *http.Request, day, part int) {
+	dc, err := challenge.Lookup(s.opts.Year, day)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err)
+		return
+	}
+
+	input, err := io.ReadAll(http.MaxBytesReader(w, r.Body, s.opts.MaxInput))
+	if err != nil {
+		writeError(w, http.StatusRequestEntityTooLarge, fmt.Errorf("puzzle input over %d bytes", s.opts.MaxInput))
+		return
+	}
+
+	select {
+	case s.slots <- struct{}{}:
+	default:
+		w.Header().Set("Retry-After", "1")
+		writeError(w, http.StatusServiceUnavailable, fmt.Errorf("too many solves running, at most %d", s.opts.Concurrency))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), s.opts.Timeout)
+	defer cancel()
+
+	type solved struct {
+		result *challenge.Result
+		err    error
+	}
+	done := make(chan solved, 1)
+	start := time.Now()
+	go func() {
+		// the slot is held until the solve really ends, which may be after the response
+		// for challenges that can't be stopped early
+		defer func() { <-s.slots }()
+		result, err := challenge.Solve(solveContext(ctx, dc), dc, part, bytes.NewReader(input))
+		done <- solved{result, err}
+	}()
+
+	sol := Solution{Day: day, Part: part}
+	status := http.StatusOK
+	select {
+	case d := <-done:
+		sol.DurationNS = time.Since(start).Nanoseconds()
+		switch {
+		case d.err == nil:
+			sol.Answer, sol.Artifacts = d.result.Answer, d.result.Artifacts
+		case errors.Is(d.err, context.DeadlineExceeded):
+			sol.Error, status = timedOut(s.opts.Timeout), http.StatusGatewayTimeout
+		default:
+			sol.Error, status = d.err.Error(), http.StatusUnprocessableEntity
+		}
+	case <-ctx.Done():
+		sol.DurationNS = time.Since(start).Nanoseconds()
+		sol.Error, status = timedOut(s.opts.Timeout), http.StatusGatewayTimeout
+	}
+
+	writeJSON(w, status, sol)
+}
+
+// solveContext returns the context to solve dc with. Challenges that can't be stopped
+// early are solved without a deadline, so that the solve ends only once they do.
+func solveContext(ctx context.Context, dc challenge.DailyChallenge) context.Context {
+	if _, ok := dc.(challenge.ContextChallenge); ok {
+		return ctx
+	}
+	return context.Background()
+}
+
+func timedOut(timeout time.Duration) string {
+	return fmt.Sprintf("timed out after %s", timeout)
+}
+
+func notAllowed(w http.ResponseWriter, allow string) {
+	w.Header().Set("Allow", allow)
+	writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method not allowed, want %s", allow))
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, failure{Error: err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
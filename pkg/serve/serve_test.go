@@ -0,0 +1,161 @@
+package serve
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/ryderlewis/aoc2021/pkg/challenge"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// year holds the fake challenges below, so the real ones needn't be registered
+const year = 1999
+
+// adder sums the numbers in its input, one per line, doubling the sum for part 2
+type adder struct{}
+
+func (adder) Challenge1(input io.Reader) (string, error) { return add(input, 1) }
+func (adder) Challenge2(input io.Reader) (string, error) { return add(input, 2) }
+
+func add(input io.Reader, factor int) (string, error) {
+	b, err := io.ReadAll(input)
+	if err != nil {
+		return "", err
+	}
+
+	sum := 0
+	for _, line := range strings.Fields(string(b)) {
+		n, err := strconv.Atoi(line)
+		if err != nil {
+			return "", fmt.Errorf("Invalid number %q", line)
+		}
+		sum += n
+	}
+
+	return strconv.Itoa(sum * factor), nil
+}
+
+// blocker doesn't answer until release is closed
+type blocker struct{}
+
+var release chan struct{}
+
+func (blocker) Challenge1(input io.Reader) (string, error) {
+	<-release
+	return "done", nil
+}
+
+func (blocker) Challenge2(input io.Reader) (string, error) {
+	return blocker{}.Challenge1(input)
+}
+
+func init() {
+	challenge.Register(year, 1, func() challenge.DailyChallenge { return adder{} })
+	challenge.Register(year, 2, func() challenge.DailyChallenge { return blocker{} })
+}
+
+// do sends a request to s, returning the status and decoding the response into v
+func do(t *testing.T, s *Server, method, path, body string, v interface{}) int {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, httptest.NewRequest(method, path, strings.NewReader(body)))
+	if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("%s %s: got content type %q", method, path, ct)
+	}
+	if err := json.NewDecoder(w.Body).Decode(v); err != nil {
+		t.Fatalf("%s %s: %v", method, path, err)
+	}
+
+	return w.Code
+}
+
+func TestList(t *testing.T) {
+	var listing Listing
+	if code := do(t, New(Options{Year: year}), "GET", "/v1/days", "", &listing); code != http.StatusOK {
+		t.Fatalf("got status %d", code)
+	}
+
+	if listing.Year != year || len(listing.Days) != 2 || listing.Days[1].Day != 2 || len(listing.Days[1].Parts) != 2 {
+		t.Errorf("got %+v", listing)
+	}
+}
+
+func TestSolve(t *testing.T) {
+	s := New(Options{Year: year})
+
+	var sol Solution
+	if code := do(t, s, "POST", "/v1/days/1/parts/2", "1\n2\n3\n", &sol); code != http.StatusOK {
+		t.Fatalf("got status %d: %+v", code, sol)
+	}
+	if sol.Day != 1 || sol.Part != 2 || sol.Answer != "12" || sol.Error != "" {
+		t.Errorf("got %+v", sol)
+	}
+
+	sol = Solution{}
+	if code := do(t, s, "POST", "/v1/days/1/parts/1", "1\nx\n", &sol); code != http.StatusUnprocessableEntity {
+		t.Errorf("got status %d, want %d", code, http.StatusUnprocessableEntity)
+	}
+	if sol.Answer != "" || sol.Error != `Invalid number "x"` {
+		t.Errorf("got %+v", sol)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	s := New(Options{Year: year, MaxInput: 4})
+
+	tests := []struct {
+		method, path, body string
+		want               int
+	}{
+		{"POST", "/v1/days/1/parts/1", "12345", http.StatusRequestEntityTooLarge},
+		{"POST", "/v1/days/3/parts/1", "", http.StatusNotFound},
+		{"POST", "/v1/days/26/parts/1", "", http.StatusNotFound},
+		{"POST", "/v1/days/1/parts/3", "", http.StatusNotFound},
+		{"POST", "/v1/days/one/parts/1", "", http.StatusNotFound},
+		{"GET", "/v1/days/1/parts/1", "", http.StatusMethodNotAllowed},
+		{"POST", "/v1/days", "", http.StatusMethodNotAllowed},
+		{"GET", "/v2/days", "", http.StatusNotFound},
+	}
+	for _, test := range tests {
+		var f failure
+		if code := do(t, s, test.method, test.path, test.body, &f); code != test.want {
+			t.Errorf("%s %s: got status %d, want %d", test.method, test.path, code, test.want)
+		}
+		if f.Error == "" {
+			t.Errorf("%s %s: got no error", test.method, test.path)
+		}
+	}
+}
+
+func TestLimits(t *testing.T) {
+	release = make(chan struct{})
+	s := New(Options{Year: year, Timeout: 10 * time.Millisecond, Concurrency: 1})
+
+	var sol Solution
+	if code := do(t, s, "POST", "/v1/days/2/parts/1", "", &sol); code != http.StatusGatewayTimeout {
+		t.Errorf("got status %d, want %d", code, http.StatusGatewayTimeout)
+	}
+	if sol.Error != "timed out after 10ms" {
+		t.Errorf("got %+v", sol)
+	}
+
+	// the timed out solve is still running, so there's no room for another
+	var f failure
+	if code := do(t, s, "POST", "/v1/days/1/parts/1", "1", &f); code != http.StatusServiceUnavailable {
+		t.Errorf("got status %d, want %d", code, http.StatusServiceUnavailable)
+	}
+
+	close(release)
+	for len(s.slots) > 0 {
+		time.Sleep(time.Millisecond)
+	}
+	if code := do(t, s, "POST", "/v1/days/1/parts/1", "1", &sol); code != http.StatusOK {
+		t.Errorf("got status %d once the solve finished", code)
+	}
+}
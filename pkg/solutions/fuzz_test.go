@@ -0,0 +1,92 @@
+package solutions
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/ryderlewis/aoc2021/pkg/challenge"
+	"github.com/ryderlewis/aoc2021/pkg/gen"
+	"testing"
+	"time"
+)
+
+// fuzzTimeout is how long each part may run on a fuzzed input. Some puzzles are
+// exponential in the size of the input, so a solve that runs out of time is not a
+// failure, but a panic always is.
+const fuzzTimeout = 2 * time.Second
+
+// fuzzDay checks that whatever the input, each part of a day's challenge gives either an
+// answer or an error. The corpus is seeded with the day's example, generated inputs and
+// the malformed inputs from TestParseErrors and TestSolveErrors.
+//
+// Run a single day with, for example: go test ./pkg/solutions -run '^$' -fuzz FuzzDay16
+func fuzzDay(f *testing.F, day int) {
+	if examples, err := readInputs(year, day, true); err == nil {
+		for _, ex := range examples {
+			f.Add(ex.Text)
+		}
+	}
+	for _, scale := range []int{1, 2, 3} {
+		if input, err := gen.Generate(day, gen.Options{Seed: int64(scale), Scale: scale}); err == nil {
+			f.Add(input.Text)
+		}
+	}
+	for _, test := range parseErrorTests {
+		if test.day == day {
+			f.Add([]byte(test.input))
+		}
+	}
+	for _, test := range solveErrorTests {
+		if test.day == day {
+			f.Add([]byte(test.input))
+		}
+	}
+	f.Add([]byte{})
+	f.Add([]byte("\n"))
+
+	f.Fuzz(func(t *testing.T, input []byte) {
+		for part := 1; part <= 2; part++ {
+			dc, err := challenge.Lookup(year, day)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), fuzzTimeout)
+			result, err := challenge.Solve(ctx, dc, part, bytes.NewReader(input))
+			cancel()
+
+			switch {
+			case errors.Is(err, context.DeadlineExceeded):
+				return
+			case err == nil && result.Answer == "":
+				t.Errorf("part %d: no answer and no error", part)
+			}
+		}
+	})
+}
+
+func FuzzDay01(f *testing.F) { fuzzDay(f, 1) }
+func FuzzDay02(f *testing.F) { fuzzDay(f, 2) }
+func FuzzDay03(f *testing.F) { fuzzDay(f, 3) }
+func FuzzDay04(f *testing.F) { fuzzDay(f, 4) }
+func FuzzDay05(f *testing.F) { fuzzDay(f, 5) }
+func FuzzDay06(f *testing.F) { fuzzDay(f, 6) }
+func FuzzDay07(f *testing.F) { fuzzDay(f, 7) }
+func FuzzDay08(f *testing.F) { fuzzDay(f, 8) }
+func FuzzDay09(f *testing.F) { fuzzDay(f, 9) }
+func FuzzDay10(f *testing.F) { fuzzDay(f, 10) }
+func FuzzDay11(f *testing.F) { fuzzDay(f, 11) }
+func FuzzDay12(f *testing.F) { fuzzDay(f, 12) }
+func FuzzDay13(f *testing.F) { fuzzDay(f, 13) }
+func FuzzDay14(f *testing.F) { fuzzDay(f, 14) }
+func FuzzDay15(f *testing.F) { fuzzDay(f, 15) }
+func FuzzDay16(f *testing.F) { fuzzDay(f, 16) }
+func FuzzDay17(f *testing.F) { fuzzDay(f, 17) }
+func FuzzDay18(f *testing.F) { fuzzDay(f, 18) }
+func FuzzDay19(f *testing.F) { fuzzDay(f, 19) }
+func FuzzDay20(f *testing.F) { fuzzDay(f, 20) }
+func FuzzDay21(f *testing.F) { fuzzDay(f, 21) }
+func FuzzDay22(f *testing.F) { fuzzDay(f, 22) }
+func FuzzDay23(f *testing.F) { fuzzDay(f, 23) }
+func FuzzDay24(f *testing.F) { fuzzDay(f, 24) }
+func FuzzDay25(f *testing.F) { fuzzDay(f, 25) }
@@ -0,0 +1,7 @@
+// Package solutions links every year's challenges into a binary. Importing it for its
+// side effects registers all of the solutions with the challenge registry.
+package solutions
+
+import (
+	_ "github.com/ryderlewis/aoc2021/pkg/y2021"
+)
@@ -0,0 +1,339 @@
+package solutions
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ryderlewis/aoc2021/pkg/challenge"
+	"github.com/ryderlewis/aoc2021/pkg/inputs"
+	"github.com/ryderlewis/aoc2021/pkg/parse"
+	"github.com/ryderlewis/aoc2021/pkg/plugin"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// year is the year covered by the parse error tests and the fuzzers
+const year = 2021
+
+var inputDir = filepath.Join("..", "..", inputs.DefaultDir)
+
+// TestMain registers the days solved by external programs alongside the native ones, so
+// that their answers are checked too
+func TestMain(m *testing.M) {
+	err := plugin.RegisterFile(filepath.Join("..", "..", plugin.DefaultConfig))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		fmt.Fprintf(os.Stderr, "registering plugins: %v\n", err)
+		os.Exit(1)
+	}
+
+	os.Exit(m.Run())
+}
+
+// TestAnswers runs every registered challenge against the example and real inputs and
+// compares the results with the answer manifest. Each example in an example file holding
+// several is run on its own. Real inputs are skipped in short mode.
+func TestAnswers(t *testing.T) {
+	for _, year := range challenge.Years() {
+		answers, err := inputs.LoadAnswers(inputDir, year)
+		if err != nil {
+			t.Fatalf("loading answers for %d: %v", year, err)
+		}
+
+		for _, day := range challenge.Days(year) {
+			for _, part := range []int{1, 2} {
+				for _, example := range []bool{true, false} {
+					year, day, part, example := year, day, part, example
+					name := inputs.Name(day, example)
+
+					t.Run(fmt.Sprintf("%d/day%02d/part%d/%s", year, day, part, name), func(t *testing.T) {
+						if testing.Short() && !example {
+							t.Skip("skipping real input in short mode")
+						}
+
+						examples, err := readInputs(year, day, example)
+						if err != nil {
+							t.Fatal(err)
+						}
+
+						for _, ex := range examples {
+							key := inputs.AnswerKey(day, example, ex.Name)
+							want, ok := answers.Lookup(day, part, key)
+							if !ok {
+								t.Errorf("%s: no answer in %s", key, inputs.AnswersFile)
+								continue
+							}
+
+							got, err := solve(year, day, part, ex.Text)
+							if err != nil {
+								t.Errorf("%s: unexpected error: %v", key, err)
+							} else if got != want {
+								t.Errorf("%s: got %q, want %q", key, got, want)
+							}
+						}
+					})
+				}
+			}
+		}
+	}
+}
+
+// TestParseOnce loads each example input once and solves both parts from the same
+// parsed puzzle, which catches parts that modify the puzzle they are given
+func TestParseOnce(t *testing.T) {
+	for _, year := range challenge.Years() {
+		answers, err := inputs.LoadAnswers(inputDir, year)
+		if err != nil {
+			t.Fatalf("loading answers for %d: %v", year, err)
+		}
+
+		for _, day := range challenge.Days(year) {
+			year, day := year, day
+			name := inputs.Name(day, true)
+
+			t.Run(fmt.Sprintf("%d/day%02d/%s", year, day, name), func(t *testing.T) {
+				dc, err := challenge.Lookup(year, day)
+				if err != nil {
+					t.Fatal(err)
+				}
+				pc, ok := dc.(challenge.PhasedChallenge)
+				if !ok {
+					t.Skip("not a phased challenge")
+				}
+
+				examples, err := readInputs(year, day, true)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				for _, ex := range examples {
+					key := inputs.AnswerKey(day, true, ex.Name)
+					if err := pc.Load(bytes.NewReader(ex.Text)); err != nil {
+						t.Errorf("%s: unexpected error: %v", key, err)
+						continue
+					}
+
+					// solve each part twice, in both orders
+					for _, part := range []int{1, 2, 2, 1} {
+						want, ok := answers.Lookup(day, part, key)
+						if !ok {
+							t.Errorf("%s: no answer in %s", key, inputs.AnswersFile)
+							continue
+						}
+
+						solve := pc.Solve1
+						if part == 2 {
+							solve = pc.Solve2
+						}
+						got, err := solve()
+						if err != nil {
+							t.Errorf("%s part %d: unexpected error: %v", key, part, err)
+						} else if got != want {
+							t.Errorf("%s part %d: got %q, want %q", key, part, got, want)
+						}
+					}
+				}
+			})
+		}
+	}
+}
+
+// TestExplain explains each example input for the days that can, and checks that the
+// explanation reaches the same answer
+func TestExplain(t *testing.T) {
+	for _, year := range challenge.Years() {
+		answers, err := inputs.LoadAnswers(inputDir, year)
+		if err != nil {
+			t.Fatalf("loading answers for %d: %v", year, err)
+		}
+
+		for _, day := range challenge.Days(year) {
+			for _, part := range []int{1, 2} {
+				year, day, part := year, day, part
+				name := inputs.Name(day, true)
+
+				t.Run(fmt.Sprintf("%d/day%02d/part%d/%s", year, day, part, name), func(t *testing.T) {
+					dc, err := challenge.Lookup(year, day)
+					if err != nil {
+						t.Fatal(err)
+					}
+					if _, ok := dc.(challenge.Explainer); !ok {
+						t.Skip("can't be explained")
+					}
+
+					examples, err := readInputs(year, day, true)
+					if err != nil {
+						t.Fatal(err)
+					}
+
+					for _, ex := range examples {
+						key := inputs.AnswerKey(day, true, ex.Name)
+						e, err := challenge.Explain(context.Background(), dc, part, bytes.NewReader(ex.Text))
+						if err != nil {
+							t.Errorf("%s: unexpected error: %v", key, err)
+							continue
+						}
+						if want, _ := answers.Lookup(day, part, key); e.Answer != want {
+							t.Errorf("%s: got %q, want %q", key, e.Answer, want)
+						}
+						if len(e.Steps) == 0 {
+							t.Errorf("%s: got no steps", key)
+						}
+					}
+				})
+			}
+		}
+	}
+}
+
+// parseErrorTests are malformed inputs, and where each day's parser should report the
+// problem
+var parseErrorTests = []struct {
+	day    int
+	input  string
+	line   int
+	column int
+	err    error
+}{
+	{1, "199\n2OO\n", 2, 1, parse.ErrInteger},
+	{2, "forward 5\nbackward 3\n", 2, 1, parse.ErrSyntax},
+	{2, "forward\n", 1, 1, parse.ErrFieldCount},
+	{3, "00100\n11110\n1011\n", 3, 0, parse.ErrSyntax},
+	{3, "", 1, 0, parse.ErrTruncated},
+	{4, "7,4,9\n\n22 13 17 11  0\n 8  2 23  4 24\n", 5, 0, parse.ErrTruncated},
+	{4, "7,4,x\n", 1, 5, parse.ErrInteger},
+	{5, "0,9 -> 5,9\n8,0 -> 0\n", 2, 1, parse.ErrSyntax},
+	{5, "0,9 -> 5,9\n0,9 -> 2,8\n", 2, 0, parse.ErrSyntax},
+	{5, "0,9 -> 5,9\n0,9 -> 2001,9\n", 2, 8, parse.ErrInteger},
+	{6, "3,4,9,1\n", 1, 5, parse.ErrInteger},
+	{7, "16,1,,0\n", 1, 6, parse.ErrInteger},
+	{8, "ab cd | ef\n", 1, 1, parse.ErrFieldCount},
+	{8, "aa b c d e f g ab abc abcd | ab ab ab ab\n", 1, 2, parse.ErrCharacter},
+	{9, "2199\n39x7\n", 2, 3, parse.ErrCharacter},
+	{10, "[({(<(())[]>[[{[]{<()<>>\n[a]\n", 2, 2, parse.ErrCharacter},
+	{11, "5483\n274\n", 2, 0, parse.ErrSyntax},
+	{12, "start-A\nA-end\nb_d\n", 3, 1, parse.ErrSyntax},
+	{12, "start-A\n", 2, 0, parse.ErrTruncated},
+	{13, "6,10\n0,14\n", 3, 0, parse.ErrTruncated},
+	{13, "6,10\n\nfold along z=7\n", 3, 1, parse.ErrSyntax},
+	{14, "NNCB\n\nCH -> \n", 3, 1, parse.ErrSyntax},
+	{15, "1163\n13a8\n", 2, 3, parse.ErrCharacter},
+	{15, "10\n09\n00\n00\n78\n00\n", 1, 2, parse.ErrCharacter},
+	{16, "8A004A801A8002F4\n", 1, 17, parse.ErrTruncated},
+	{16, "16004408\n", 1, 1, parse.ErrSyntax},
+	{16, "D2FE2\n", 1, 6, parse.ErrTruncated},
+	{17, "target area: x=20..30, y=-10..-x\n", 1, 1, parse.ErrSyntax},
+	{17, "target area: x=30..20, y=-10..-5\n", 1, 20, parse.ErrInteger},
+	{17, "target area: x=20..30, y=5..10\n", 1, 26, parse.ErrInteger},
+	{18, "[[1,2],3\n", 1, 9, parse.ErrTruncated},
+	{18, "[[[[[1,2],3],4],5],6]\n", 1, 5, parse.ErrSyntax},
+	{19, "--- scanner 0 ---\n404,-588\n", 2, 1, parse.ErrFieldCount},
+	{19, "--- scanner 1 ---\n", 1, 13, parse.ErrSyntax},
+	{20, "..#\n\n#..#.\n", 1, 1, parse.ErrSyntax},
+	{21, "Player 1 starting position: 4\nPlayer 2 starting position: 11\n", 2, 29, parse.ErrInteger},
+	{22, "on x=10..12,y=10..12,z=10..1a\n", 1, 1, parse.ErrSyntax},
+	{22, "on x=10..12,y=10..12,z=10..9\n", 1, 28, parse.ErrInteger},
+	{23, "#############\n#...........#\n###B#C#B#D###\n", 4, 0, parse.ErrTruncated},
+	{23, "#############\n#...........#\n###B#C#B#D###\n  #A#D#B#A#\n  #########\n", 4, 8, parse.ErrSyntax},
+	{24, "inp w\nadd x\n", 2, 1, parse.ErrSyntax},
+	{24, "", 1, 0, parse.ErrTruncated},
+	{25, "v...>>.vv>\n.vv>>.vv..\n>>.>v>...v\n>>v>>.>.v?\n", 4, 10, parse.ErrCharacter},
+}
+
+// TestParseErrors feeds each day malformed input, and checks that it is rejected with an
+// error pointing at the problem rather than a panic or a wrong answer
+func TestParseErrors(t *testing.T) {
+	for _, test := range parseErrorTests {
+		test := test
+
+		t.Run(fmt.Sprintf("day%02d/%q", test.day, test.input), func(t *testing.T) {
+			dc, err := challenge.Lookup(year, test.day)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = dc.Challenge1(strings.NewReader(test.input))
+			var perr *parse.Error
+			if !errors.As(err, &perr) {
+				t.Fatalf("got %v, want *parse.Error", err)
+			}
+			if !errors.Is(err, test.err) {
+				t.Errorf("got %v, want %v", err, test.err)
+			}
+			if perr.Line != test.line || perr.Column != test.column {
+				t.Errorf("got line %d, column %d, want line %d, column %d (%v)", perr.Line, perr.Column, test.line, test.column, err)
+			}
+		})
+	}
+}
+
+// solveErrorTests are inputs that parse, but that no answer can be found for
+var solveErrorTests = []struct {
+	day   int
+	part  int
+	input string
+	want  string
+}{
+	{8, 2, "a b c d e f g ab abc abcd | ab ab ab ab\n", "No wiring decodes entry 1"},
+}
+
+// TestSolveErrors checks that inputs without an answer give an error rather than a wrong
+// answer
+func TestSolveErrors(t *testing.T) {
+	for _, test := range solveErrorTests {
+		dc, err := challenge.Lookup(year, test.day)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		answer, err := challenge.Solve(context.Background(), dc, test.part, strings.NewReader(test.input))
+		if err == nil || err.Error() != test.want {
+			t.Errorf("day %d part %d: got %+v, %v, want error %q", test.day, test.part, answer, err, test.want)
+		}
+	}
+}
+
+// TestAnswersRegistered makes sure the manifests don't refer to days that no longer exist
+func TestAnswersRegistered(t *testing.T) {
+	for _, year := range challenge.Years() {
+		answers, err := inputs.LoadAnswers(inputDir, year)
+		if err != nil {
+			t.Fatalf("loading answers for %d: %v", year, err)
+		}
+
+		for day := range answers {
+			if _, err := challenge.Lookup(year, day); err != nil {
+				t.Errorf("answers for day %d: %v", day, err)
+			}
+		}
+	}
+}
+
+// readInputs reads a day's example or real input. An example file holding several
+// examples gives each of them, as the -example flag runs them.
+func readInputs(year, day int, example bool) ([]inputs.Example, error) {
+	path := inputs.Path(inputDir, year, day, example)
+	if example {
+		return inputs.ReadExamples(path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return []inputs.Example{{Text: data}}, nil
+}
+
+func solve(year, day, part int, input []byte) (string, error) {
+	dc, err := challenge.Lookup(year, day)
+	if err != nil {
+		return "", err
+	}
+
+	if part == 1 {
+		return dc.Challenge1(bytes.NewReader(input))
+	}
+	return dc.Challenge2(bytes.NewReader(input))
+}
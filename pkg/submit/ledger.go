@@ -0,0 +1,122 @@
+package submit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// LedgerFile is the name of the ledger, kept in the inputs directory
+const LedgerFile = "ledger.json"
+
+var (
+	ErrKnownBad      = errors.New("answer already rejected")
+	ErrAlreadySolved = errors.New("already solved")
+	ErrWait          = errors.New("submitted too recently")
+)
+
+// Attempt is a single submitted answer and the site's verdict on it
+type Attempt struct {
+	Year    int           `json:"year"`
+	Day     int           `json:"day"`
+	Part    int           `json:"part"`
+	Answer  string        `json:"answer"`
+	Verdict Verdict       `json:"verdict"`
+	Time    time.Time     `json:"time"`
+	Wait    time.Duration `json:"wait,omitempty"` // how long the site asked us to wait, if it did
+}
+
+// Ledger is every attempt made so far, in order. It is used to avoid submitting answers
+// that are already known to be wrong.
+type Ledger struct {
+	path     string
+	Attempts []Attempt
+}
+
+// LoadLedger reads the ledger at path. A missing file is an empty ledger.
+func LoadLedger(path string) (*Ledger, error) {
+	l := &Ledger{path: path}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return l, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &l.Attempts); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	return l, nil
+}
+
+// Check returns an error if answer shouldn't be submitted, because it has already been
+// rejected, falls outside a too high or too low bound, the part has already been solved
+// or the site asked us to wait
+func (l *Ledger) Check(year, day, part int, answer string, now time.Time) error {
+	value, numeric := parseAnswer(answer)
+
+	for _, a := range l.Attempts {
+		if a.Year != year || a.Day != day || a.Part != part {
+			continue
+		}
+
+		switch a.Verdict {
+		case Correct:
+			return fmt.Errorf("%w: answer was %s", ErrAlreadySolved, a.Answer)
+		case RateLimited:
+			if until := a.Time.Add(a.Wait); now.Before(until) {
+				return fmt.Errorf("%w: wait until %s", ErrWait, until.Format(time.Kitchen))
+			}
+		case Wrong, TooHigh, TooLow:
+			if a.Answer == answer {
+				return fmt.Errorf("%w: %s was %s", ErrKnownBad, answer, a.Verdict)
+			}
+		}
+
+		if bound, ok := parseAnswer(a.Answer); ok && numeric {
+			if a.Verdict == TooHigh && value >= bound {
+				return fmt.Errorf("%w: %s was too high", ErrKnownBad, a.Answer)
+			}
+			if a.Verdict == TooLow && value <= bound {
+				return fmt.Errorf("%w: %s was too low", ErrKnownBad, a.Answer)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Record adds an attempt to the ledger and saves it
+func (l *Ledger) Record(a Attempt) error {
+	l.Attempts = append(l.Attempts, a)
+	return l.save()
+}
+
+// save writes the ledger, replacing the old file only once the new one is complete
+func (l *Ledger) save() error {
+	data, err := json.MarshalIndent(l.Attempts, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(l.path), 0755); err != nil {
+		return err
+	}
+	tmp := l.path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, l.path)
+}
+
+func parseAnswer(answer string) (int64, bool) {
+	v, err := strconv.ParseInt(answer, 10, 64)
+	return v, err == nil
+}
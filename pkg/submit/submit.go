@@ -0,0 +1,163 @@
+// Package submit posts answers to the puzzle site and keeps a ledger of every attempt,
+// so that answers already known to be wrong are never sent twice.
+package submit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultBaseURL is the site answers are submitted to
+const DefaultBaseURL = "https://adventofcode.com"
+
+// DefaultTimeout is how long the default HTTP client waits for each submission
+const DefaultTimeout = time.Minute
+
+const userAgent = "github.com/ryderlewis/aoc2021/pkg/submit"
+
+var ErrNoSession = errors.New("no session token")
+
+// defaultHTTPClient gives up on a site that stalls, unlike http.DefaultClient
+var defaultHTTPClient = &http.Client{Timeout: DefaultTimeout}
+
+// Verdict is the site's response to an answer
+type Verdict string
+
+const (
+	Correct     Verdict = "correct"
+	Wrong       Verdict = "wrong"
+	TooHigh     Verdict = "too high"
+	TooLow      Verdict = "too low"
+	RateLimited Verdict = "rate limited"
+	WrongLevel  Verdict = "wrong level" // the part is locked or already solved
+	Unknown     Verdict = "unknown"
+)
+
+// Client submits answers, checking them against and recording them in Ledger
+type Client struct {
+	BaseURL    string
+	Session    string
+	Ledger     *Ledger
+	HTTPClient *http.Client
+	Now        func() time.Time
+}
+
+// New returns a client that submits to the real site
+func New(session string, ledger *Ledger) *Client {
+	return &Client{
+		BaseURL:    DefaultBaseURL,
+		Session:    session,
+		Ledger:     ledger,
+		HTTPClient: defaultHTTPClient,
+		Now:        time.Now,
+	}
+}
+
+// Submit posts the answer to one part of a day's puzzle, unless the ledger says it
+// would be pointless. Every attempt that reaches the site is recorded in the ledger.
+func (c *Client) Submit(ctx context.Context, year, day, part int, answer string) (*Attempt, error) {
+	if c.Session == "" {
+		return nil, ErrNoSession
+	}
+	answer = strings.TrimSpace(answer)
+	if answer == "" {
+		return nil, fmt.Errorf("empty answer")
+	}
+	if err := c.Ledger.Check(year, day, part, answer, c.now()); err != nil {
+		return nil, err
+	}
+
+	endpoint := fmt.Sprintf("%s/%d/day/%d/answer", strings.TrimSuffix(c.BaseURL, "/"), year, day)
+	form := url.Values{
+		"level":  {fmt.Sprint(part)},
+		"answer": {answer},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("User-Agent", userAgent)
+	req.AddCookie(&http.Cookie{Name: "session", Value: c.Session})
+
+	resp, err := c.httpClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("submitting to %s: %s", endpoint, resp.Status)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	attempt := &Attempt{
+		Year:   year,
+		Day:    day,
+		Part:   part,
+		Answer: answer,
+		Time:   c.now(),
+	}
+	attempt.Verdict, attempt.Wait = ParseResponse(string(body))
+
+	if err := c.Ledger.Record(*attempt); err != nil {
+		return attempt, err
+	}
+
+	return attempt, nil
+}
+
+func (c *Client) httpClient() *http.Client {
+	if c.HTTPClient != nil {
+		return c.HTTPClient
+	}
+	return defaultHTTPClient
+}
+
+func (c *Client) now() time.Time {
+	if c.Now != nil {
+		return c.Now()
+	}
+	return time.Now()
+}
+
+var waitPattern = regexp.MustCompile(`You have (?:(\d+)m )?(\d+)s left to wait`)
+
+// ParseResponse reads the verdict from the page the site returns after an answer is
+// submitted. For rate limited answers it also returns how long the site asked us to wait.
+func ParseResponse(page string) (Verdict, time.Duration) {
+	switch {
+	case strings.Contains(page, "That's the right answer"):
+		return Correct, 0
+	case strings.Contains(page, "your answer is too high"):
+		return TooHigh, 0
+	case strings.Contains(page, "your answer is too low"):
+		return TooLow, 0
+	case strings.Contains(page, "That's not the right answer"):
+		return Wrong, 0
+	case strings.Contains(page, "You gave an answer too recently"):
+		var wait time.Duration
+		if m := waitPattern.FindStringSubmatch(page); m != nil {
+			minutes, _ := strconv.Atoi(m[1])
+			seconds, _ := strconv.Atoi(m[2])
+			wait = time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second
+		}
+		return RateLimited, wait
+	case strings.Contains(page, "You don't seem to be solving the right level"):
+		return WrongLevel, 0
+	default:
+		return Unknown, 0
+	}
+}
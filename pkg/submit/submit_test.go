@@ -0,0 +1,181 @@
+package submit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const session = "53616c7465645f5f"
+
+// pages are trimmed down versions of the site's responses
+var pages = map[Verdict]string{
+	Correct:     `<article><p>That's the right answer!  You are one gold star closer to saving your vacation.</p></article>`,
+	Wrong:       `<article><p>That's not the right answer.  If you're stuck, make sure you're using the full input data.</p></article>`,
+	TooHigh:     `<article><p>That's not the right answer; your answer is too high.  Please wait one minute before trying again.</p></article>`,
+	TooLow:      `<article><p>That's not the right answer; your answer is too low.  Please wait one minute before trying again.</p></article>`,
+	RateLimited: `<article><p>You gave an answer too recently; you have to wait after submitting an answer before trying again.  You have 1m 7s left to wait.</p></article>`,
+	WrongLevel:  `<article><p>You don't seem to be solving the right level.  Did you already complete it?</p></article>`,
+}
+
+func TestParseResponse(t *testing.T) {
+	for want, page := range pages {
+		got, wait := ParseResponse(page)
+		if got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+		if want == RateLimited && wait != time.Minute+7*time.Second {
+			t.Errorf("got wait %s, want 1m7s", wait)
+		}
+	}
+
+	if got, _ := ParseResponse("<html></html>"); got != Unknown {
+		t.Errorf("got %q, want %q", got, Unknown)
+	}
+}
+
+// newServer stands in for the site. Part 1 of day 1 has the answer 1451; any other
+// answer is judged too high or too low, and part 2 is always rate limited.
+func newServer(t *testing.T, posts *int) *httptest.Server {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*posts++
+
+		if r.Method != http.MethodPost || r.URL.Path != "/2021/day/1/answer" {
+			http.NotFound(w, r)
+			return
+		}
+		if c, err := r.Cookie("session"); err != nil || c.Value != session {
+			http.Error(w, "Bad request", http.StatusBadRequest)
+			return
+		}
+
+		var answer int
+		fmt.Sscan(r.PostFormValue("answer"), &answer)
+		switch {
+		case r.PostFormValue("level") == "2":
+			fmt.Fprint(w, pages[RateLimited])
+		case answer > 1451:
+			fmt.Fprint(w, pages[TooHigh])
+		case answer < 1451:
+			fmt.Fprint(w, pages[TooLow])
+		default:
+			fmt.Fprint(w, pages[Correct])
+		}
+	}))
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+func newClient(t *testing.T, s *httptest.Server) *Client {
+	ledger, err := LoadLedger(filepath.Join(t.TempDir(), LedgerFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := New(session, ledger)
+	c.BaseURL = s.URL
+	c.HTTPClient = s.Client()
+	return c
+}
+
+func TestSubmit(t *testing.T) {
+	posts := 0
+	s := newServer(t, &posts)
+	c := newClient(t, s)
+	ctx := context.Background()
+
+	attempts := []struct {
+		answer  string
+		verdict Verdict
+		err     error
+	}{
+		{"2000", TooHigh, nil},
+		{"2000", "", ErrKnownBad}, // rejected before
+		{"2001", "", ErrKnownBad}, // above a too high answer
+		{"1000", TooLow, nil},
+		{"999", "", ErrKnownBad}, // below a too low answer
+		{"1451", Correct, nil},
+		{"1452", "", ErrAlreadySolved},
+	}
+
+	wantPosts := 0
+	for _, a := range attempts {
+		attempt, err := c.Submit(ctx, 2021, 1, 1, a.answer)
+		if a.err != nil {
+			if !errors.Is(err, a.err) {
+				t.Errorf("answer %s: got %v, want %v", a.answer, err, a.err)
+			}
+			continue
+		}
+
+		wantPosts++
+		if err != nil {
+			t.Fatalf("answer %s: unexpected error: %v", a.answer, err)
+		}
+		if attempt.Verdict != a.verdict {
+			t.Errorf("answer %s: got %q, want %q", a.answer, attempt.Verdict, a.verdict)
+		}
+	}
+
+	if posts != wantPosts {
+		t.Errorf("got %d posts, want %d", posts, wantPosts)
+	}
+
+	// the ledger survives being reloaded
+	ledger, err := LoadLedger(c.Ledger.path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ledger.Attempts) != wantPosts {
+		t.Errorf("got %d attempts in the ledger, want %d", len(ledger.Attempts), wantPosts)
+	}
+	if err := ledger.Check(2021, 1, 1, "1452", time.Now()); !errors.Is(err, ErrAlreadySolved) {
+		t.Errorf("got %v, want ErrAlreadySolved", err)
+	}
+}
+
+func TestSubmitRateLimited(t *testing.T) {
+	posts := 0
+	s := newServer(t, &posts)
+	c := newClient(t, s)
+
+	now := time.Date(2021, 12, 1, 5, 0, 0, 0, time.UTC)
+	c.Now = func() time.Time { return now }
+
+	attempt, err := c.Submit(context.Background(), 2021, 1, 2, "1395")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if attempt.Verdict != RateLimited || attempt.Wait != time.Minute+7*time.Second {
+		t.Errorf("got %q, wait %s, want rate limited for 1m7s", attempt.Verdict, attempt.Wait)
+	}
+
+	// trying again before the wait is over doesn't reach the site
+	now = now.Add(time.Minute)
+	if _, err := c.Submit(context.Background(), 2021, 1, 2, "1395"); !errors.Is(err, ErrWait) {
+		t.Errorf("got %v, want ErrWait", err)
+	}
+
+	now = now.Add(time.Minute)
+	if _, err := c.Submit(context.Background(), 2021, 1, 2, "1395"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if posts != 2 {
+		t.Errorf("got %d posts, want 2", posts)
+	}
+}
+
+func TestDefaultTimeout(t *testing.T) {
+	if c := New(session, &Ledger{}); c.HTTPClient.Timeout != DefaultTimeout {
+		t.Errorf("got timeout %s, want %s", c.HTTPClient.Timeout, DefaultTimeout)
+	}
+	if c := (&Client{}); c.httpClient().Timeout != DefaultTimeout {
+		t.Errorf("got timeout %s for a client without an HTTP client, want %s", c.httpClient().Timeout, DefaultTimeout)
+	}
+}
@@ -0,0 +1,125 @@
+// Package trace writes diagnostics from solvers as they run, kept apart from the answers.
+// Each day writes under its own topic, and each topic can be shown in more or less
+// detail.
+package trace
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// Level is how much detail is shown
+type Level int
+
+const (
+	Off   Level = iota
+	Info        // notable findings along the way
+	Debug       // every step
+)
+
+var levelNames = map[string]Level{"off": Off, "info": Info, "debug": Debug}
+
+// Traced is implemented by challenges that can write diagnostics as they solve
+type Traced interface {
+	SetTracer(t *Tracer)
+}
+
+// Log writes diagnostics from every topic to one writer, a line at a time
+type Log struct {
+	mu     sync.Mutex
+	w      io.Writer
+	level  Level            // the level of topics not set on their own
+	topics map[string]Level // levels set for particular topics
+}
+
+// New returns a log writing to w, showing every topic at level
+func New(w io.Writer, level Level) *Log {
+	return &Log{w: w, level: level, topics: make(map[string]Level)}
+}
+
+// SetTopic shows topic at level, whatever the level of the others
+func (l *Log) SetTopic(topic string, level Level) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.topics[topic] = level
+}
+
+// SetTopics sets the level of each topic in a comma separated list such as
+// "day04,day23:info". Topics without a level are shown at Debug.
+func (l *Log) SetTopics(spec string) error {
+	for _, field := range strings.Split(spec, ",") {
+		topic, name := strings.TrimSpace(field), "debug"
+		if i := strings.IndexByte(topic, ':'); i >= 0 {
+			topic, name = topic[:i], topic[i+1:]
+		}
+
+		level, ok := levelNames[name]
+		if topic == "" || !ok {
+			return fmt.Errorf("invalid trace topic %q, want a topic such as day04, optionally followed by :info or :debug", field)
+		}
+		l.SetTopic(topic, level)
+	}
+
+	return nil
+}
+
+// Tracer returns a tracer writing under topic, or nil if the topic isn't shown at all
+func (l *Log) Tracer(topic string) *Tracer {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	level, ok := l.topics[topic]
+	if !ok {
+		level = l.level
+	}
+	l.mu.Unlock()
+
+	if level == Off {
+		return nil
+	}
+	return &Tracer{log: l, topic: topic, level: level}
+}
+
+// write writes a line under topic
+func (l *Log) write(topic, line string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	fmt.Fprintf(l.w, "%s: %s\n", topic, strings.TrimSuffix(line, "\n"))
+}
+
+// Tracer writes diagnostics under one topic. Tracing to a nil *Tracer does nothing, so
+// solvers can trace whether or not anyone is reading.
+type Tracer struct {
+	log   *Log
+	topic string
+	level Level
+}
+
+// Enabled reports whether messages at level are shown, for diagnostics that take work
+// to produce
+func (t *Tracer) Enabled(level Level) bool {
+	return t != nil && level != Off && level <= t.level
+}
+
+// Infof writes a notable finding
+func (t *Tracer) Infof(format string, args ...interface{}) {
+	t.printf(Info, format, args...)
+}
+
+// Debugf writes the details of a step
+func (t *Tracer) Debugf(format string, args ...interface{}) {
+	t.printf(Debug, format, args...)
+}
+
+func (t *Tracer) printf(level Level, format string, args ...interface{}) {
+	if !t.Enabled(level) {
+		return
+	}
+	t.log.write(t.topic, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,53 @@
+package trace
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestLevels(t *testing.T) {
+	var buf bytes.Buffer
+	log := New(&buf, Info)
+	if err := log.SetTopics("day04, day09:off"); err != nil {
+		t.Fatal(err)
+	}
+
+	day04, day09, day23 := log.Tracer("day04"), log.Tracer("day09"), log.Tracer("day23")
+	if day09 != nil {
+		t.Error("got a tracer for a topic that is off")
+	}
+
+	day04.Debugf("Calling %d", 7)
+	day09.Infof("not shown")
+	day23.Infof("Starting from\n%s\n", "#...#")
+	day23.Debugf("not shown")
+
+	want := "day04: Calling 7\nday23: Starting from\n#...#\n"
+	if buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+	if !day04.Enabled(Debug) || day23.Enabled(Debug) || day23.Enabled(Off) {
+		t.Error("got the wrong levels enabled")
+	}
+}
+
+func TestNil(t *testing.T) {
+	var log *Log
+	tr := log.Tracer("day01")
+	tr.Infof("nothing")
+
+	if tr.Enabled(Info) {
+		t.Error("a nil tracer is enabled")
+	}
+	if New(nil, Off).Tracer("day01") != nil {
+		t.Error("got a tracer from a log that is off")
+	}
+}
+
+func TestSetTopics(t *testing.T) {
+	for _, spec := range []string{"", "day04,", "day04:loud", ":info"} {
+		if err := New(nil, Off).SetTopics(spec); err == nil {
+			t.Errorf("SetTopics(%q): got no error", spec)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package viz
+
+import (
+	"fmt"
+	"image/color"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Palettes are the named palettes, each running from the color of the lowest value to the
+// color of the highest
+var Palettes = map[string]color.Palette{
+	"heat": gradient(16, rgb(0x000000), rgb(0x800000), rgb(0xff8000), rgb(0xffff00), rgb(0xffffff)),
+	"gray": gradient(16, rgb(0x000000), rgb(0xffffff)),
+	"sea":  gradient(16, rgb(0x001020), rgb(0x006080), rgb(0x80ffe0)),
+	"mono": {rgb(0x000000), rgb(0xffffff)},
+}
+
+// PaletteNames returns the names of the palettes, in order
+func PaletteNames() []string {
+	names := make([]string, 0, len(Palettes))
+	for name := range Palettes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// ParsePalette returns the palette with the given name, or else builds one from a comma
+// separated list of hex colors such as "#000000,#ff0000,#ffffff"
+func ParsePalette(s string) (color.Palette, error) {
+	if p, ok := Palettes[s]; ok {
+		return p, nil
+	}
+
+	fields := strings.Split(s, ",")
+	if len(fields) < 2 || len(fields) > 256 {
+		return nil, fmt.Errorf("unknown palette %q, want one of %s or 2 to 256 hex colors", s, strings.Join(PaletteNames(), ", "))
+	}
+
+	p := make(color.Palette, len(fields))
+	for i, field := range fields {
+		hex := strings.TrimPrefix(strings.TrimSpace(field), "#")
+		v, err := strconv.ParseUint(hex, 16, 32)
+		if err != nil || len(hex) != 6 {
+			return nil, fmt.Errorf("invalid color %q in palette, want a hex color such as #ff8000", field)
+		}
+		p[i] = rgb(uint32(v))
+	}
+
+	return p, nil
+}
+
+func rgb(v uint32) color.RGBA {
+	return color.RGBA{R: uint8(v >> 16), G: uint8(v >> 8), B: uint8(v), A: 0xff}
+}
+
+// gradient returns n colors blending evenly from each stop to the next
+func gradient(n int, stops ...color.RGBA) color.Palette {
+	p := make(color.Palette, n)
+	for i := range p {
+		// position along the stops, in units of 1/(n-1)
+		pos := i * (len(stops) - 1)
+		from, frac := pos/(n-1), pos%(n-1)
+		if from == len(stops)-1 {
+			p[i] = stops[from]
+			continue
+		}
+
+		a, b := stops[from], stops[from+1]
+		mix := func(x, y uint8) uint8 {
+			return uint8((int(x)*(n-1-frac) + int(y)*frac) / (n - 1))
+		}
+		p[i] = color.RGBA{R: mix(a.R, b.R), G: mix(a.G, b.G), B: mix(a.B, b.B), A: 0xff}
+	}
+
+	return p
+}
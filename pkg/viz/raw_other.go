@@ -0,0 +1,14 @@
+//go:build !linux && !darwin && !dragonfly && !freebsd && !netbsd && !openbsd
+// +build !linux,!darwin,!dragonfly,!freebsd,!netbsd,!openbsd
+
+package viz
+
+import (
+	"fmt"
+	"os"
+)
+
+// RawMode isn't supported here, so keys are only read once enter is pressed
+func RawMode(f *os.File) (restore func() error, err error) {
+	return nil, fmt.Errorf("raw terminal mode isn't supported")
+}
@@ -0,0 +1,37 @@
+//go:build linux || darwin || dragonfly || freebsd || netbsd || openbsd
+// +build linux darwin dragonfly freebsd netbsd openbsd
+
+package viz
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// RawMode puts the terminal f into raw mode, so that keys are read as they are pressed,
+// without waiting for enter or being echoed, and returns a function restoring it
+func RawMode(f *os.File) (restore func() error, err error) {
+	var old syscall.Termios
+	if err := termios(f, ioctlGetTermios, &old); err != nil {
+		return nil, err
+	}
+
+	raw := old
+	raw.Lflag &^= syscall.ICANON | syscall.ECHO
+	raw.Cc[syscall.VMIN] = 1
+	raw.Cc[syscall.VTIME] = 0
+	if err := termios(f, ioctlSetTermios, &raw); err != nil {
+		return nil, err
+	}
+
+	return func() error { return termios(f, ioctlSetTermios, &old) }, nil
+}
+
+// termios gets or sets the terminal settings of f
+func termios(f *os.File, req uintptr, t *syscall.Termios) error {
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), req, uintptr(unsafe.Pointer(t))); errno != 0 {
+		return errno
+	}
+	return nil
+}
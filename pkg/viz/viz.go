@@ -0,0 +1,253 @@
+// Package viz records simulations as they run, one frame per step, and saves them as
+// animated GIFs, or as a PNG of the final frame, or draws them live in the terminal.
+package viz
+
+import (
+	"context"
+	"fmt"
+	"github.com/ryderlewis/aoc2021/pkg/challenge"
+	"github.com/ryderlewis/aoc2021/pkg/grid"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Options control how frames are drawn
+type Options struct {
+	Scale   int           // width and height of each grid cell in pixels. Defaults to 4.
+	Delay   int           // time between frames in hundredths of a second. Defaults to 10.
+	Palette color.Palette // colors from the lowest value to the highest. Defaults to heat.
+}
+
+// Style describes how the values in a frame are shown
+type Style struct {
+	// Max is the value given the last color of the palette, with values from 0 to Max
+	// spread evenly across it
+	Max int
+
+	// Chars holds the character drawn in the terminal for each value from 0 up. Values
+	// past the end are drawn with the last character.
+	Chars string
+
+	// Highlight picks out the values to highlight in the terminal. If it is nil, the cells
+	// that changed since the frame before are highlighted.
+	Highlight func(v int) bool
+}
+
+// Animator is implemented by challenges that can record how they solve each part
+type Animator interface {
+	Challenge1Viz(input io.Reader, rec *Recorder) (string, error)
+	Challenge2Viz(input io.Reader, rec *Recorder) (string, error)
+}
+
+// Recorder collects the frames of an animation. Recording to a nil *Recorder does
+// nothing, so solvers can record whether or not anyone is watching.
+type Recorder struct {
+	opts  Options
+	watch *watcher // draws frames in the terminal instead of keeping them, if set
+
+	// frames are kept a pixel per grid cell, and cropped to the part that changed since
+	// the frame before, unless they are a different size
+	frames  []*image.Paletted
+	delays  []int  // how long each frame shows, in frames
+	resized []bool // whether each frame is a different size to the one before
+	last    *image.Paletted
+}
+
+// New returns a recorder drawing frames with opts
+func New(opts Options) *Recorder {
+	if opts.Scale <= 0 {
+		opts.Scale = 4
+	}
+	if opts.Delay <= 0 {
+		opts.Delay = 10
+	}
+	if len(opts.Palette) == 0 {
+		opts.Palette = Palettes["heat"]
+	}
+
+	return &Recorder{opts: opts}
+}
+
+// Frame adds a frame showing g in the given style. Values outside the range of the
+// palette take the color at the nearest end.
+func (r *Recorder) Frame(g *grid.Grid, style Style) {
+	if r == nil {
+		return
+	}
+	if r.watch != nil {
+		r.watch.frame(g, style)
+		return
+	}
+
+	img := image.NewPaletted(image.Rect(0, 0, g.Width, g.Height), r.opts.Palette)
+	for _, p := range g.Points() {
+		img.SetColorIndex(p.X, p.Y, r.index(g.Get(p), style.Max))
+	}
+
+	if r.last == nil || r.last.Rect != img.Rect {
+		r.frames = append(r.frames, img)
+		r.resized = append(r.resized, r.last != nil)
+	} else if box := changed(r.last, img); box.Empty() {
+		// nothing to show, so the frame before lasts longer
+		r.delays[len(r.delays)-1]++
+		r.last = img
+		return
+	} else {
+		r.frames = append(r.frames, img.SubImage(box).(*image.Paletted))
+		r.resized = append(r.resized, false)
+	}
+	r.delays = append(r.delays, 1)
+	r.last = img
+}
+
+// index returns the palette index for v
+func (r *Recorder) index(v, max int) uint8 {
+	last := len(r.opts.Palette) - 1
+	switch {
+	case v <= 0:
+		return 0
+	case v >= max:
+		return uint8(last)
+	default:
+		return uint8((v*last + max/2) / max)
+	}
+}
+
+// changed returns the smallest rectangle holding every pixel that differs between two
+// images of the same size
+func changed(a, b *image.Paletted) image.Rectangle {
+	box := image.Rectangle{}
+	for y := a.Rect.Min.Y; y < a.Rect.Max.Y; y++ {
+		for x := a.Rect.Min.X; x < a.Rect.Max.X; x++ {
+			if a.ColorIndexAt(x, y) != b.ColorIndexAt(x, y) {
+				box = box.Union(image.Rect(x, y, x+1, y+1))
+			}
+		}
+	}
+
+	return box
+}
+
+// scaled returns img drawn at the recorder's scale
+func (r *Recorder) scaled(img *image.Paletted) *image.Paletted {
+	scale := r.opts.Scale
+	rect := image.Rectangle{Min: img.Rect.Min.Mul(scale), Max: img.Rect.Max.Mul(scale)}
+	s := image.NewPaletted(rect, img.Palette)
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			s.SetColorIndex(x, y, img.ColorIndexAt(x/scale, y/scale))
+		}
+	}
+
+	return s
+}
+
+// Len returns the number of frames recorded, or drawn when watching
+func (r *Recorder) Len() int {
+	switch {
+	case r == nil:
+		return 0
+	case r.watch != nil:
+		return r.watch.count
+	}
+	return len(r.frames)
+}
+
+// WriteGIF writes every frame to w as an animated GIF that loops forever, lingering on
+// the final frame
+func (r *Recorder) WriteGIF(w io.Writer) error {
+	if r.Len() == 0 || r.watch != nil {
+		return fmt.Errorf("no frames recorded")
+	}
+
+	anim := &gif.GIF{
+		Image:    make([]*image.Paletted, len(r.frames)),
+		Delay:    make([]int, len(r.frames)),
+		Disposal: make([]byte, len(r.frames)),
+		Config:   image.Config{ColorModel: r.opts.Palette},
+	}
+	for i, f := range r.frames {
+		anim.Image[i] = r.scaled(f)
+		anim.Delay[i] = r.delays[i] * r.opts.Delay
+		if i+1 < len(r.frames) && r.resized[i+1] {
+			// clear a frame the next one may not cover
+			anim.Disposal[i] = gif.DisposalBackground
+		}
+
+		max := anim.Image[i].Rect.Max
+		if max.X > anim.Config.Width {
+			anim.Config.Width = max.X
+		}
+		if max.Y > anim.Config.Height {
+			anim.Config.Height = max.Y
+		}
+	}
+	anim.Delay[len(anim.Delay)-1] += 10 * r.opts.Delay
+
+	return gif.EncodeAll(w, anim)
+}
+
+// WritePNG writes the final frame to w as a PNG
+func (r *Recorder) WritePNG(w io.Writer) error {
+	if r.Len() == 0 || r.watch != nil {
+		return fmt.Errorf("no frames recorded")
+	}
+	return png.Encode(w, r.scaled(r.last))
+}
+
+// Save writes the recording to filename, as a GIF or PNG depending on its extension
+func (r *Recorder) Save(filename string) (err error) {
+	write := r.WriteGIF
+	switch ext := strings.ToLower(filepath.Ext(filename)); ext {
+	case ".gif":
+	case ".png":
+		write = r.WritePNG
+	default:
+		return fmt.Errorf("can't save a visualization as %q, want .gif or .png", ext)
+	}
+	if r.Len() == 0 || r.watch != nil {
+		return fmt.Errorf("no frames recorded")
+	}
+
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+	}()
+
+	return write(f)
+}
+
+// Solve solves one part of a challenge that can be animated, recording it to rec. Like
+// challenge.Run, it gives up when ctx is done.
+func Solve(ctx context.Context, dc challenge.DailyChallenge, part int, input io.Reader, rec *Recorder) (string, error) {
+	a, ok := dc.(Animator)
+	if !ok {
+		return "", fmt.Errorf("can't be visualized")
+	}
+	return challenge.Run(ctx, animated{a, rec}, part, input)
+}
+
+// animated adapts an Animator recording to rec to a DailyChallenge
+type animated struct {
+	a   Animator
+	rec *Recorder
+}
+
+func (x animated) Challenge1(input io.Reader) (string, error) {
+	return x.a.Challenge1Viz(input, x.rec)
+}
+
+func (x animated) Challenge2(input io.Reader) (string, error) {
+	return x.a.Challenge2Viz(input, x.rec)
+}
@@ -0,0 +1,127 @@
+package viz
+
+import (
+	"bytes"
+	"github.com/ryderlewis/aoc2021/pkg/grid"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"testing"
+)
+
+var bits = Style{Max: 1, Chars: ".#"}
+
+func TestWriteGIF(t *testing.T) {
+	rec := New(Options{Scale: 2, Delay: 5, Palette: Palettes["mono"]})
+
+	g := grid.New(4, 3)
+	rec.Frame(g, bits)
+	g.Set(grid.Point{X: 2, Y: 1}, 1)
+	rec.Frame(g, bits)
+	rec.Frame(g, bits) // unchanged, so the frame before shows for longer
+	rec.Frame(grid.New(5, 5), bits)
+
+	if rec.Len() != 3 {
+		t.Fatalf("got %d frames, want 3", rec.Len())
+	}
+
+	var buf bytes.Buffer
+	if err := rec.WriteGIF(&buf); err != nil {
+		t.Fatal(err)
+	}
+	anim, err := gif.DecodeAll(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if anim.Config.Width != 10 || anim.Config.Height != 10 {
+		t.Errorf("got a %dx%d canvas, want 10x10", anim.Config.Width, anim.Config.Height)
+	}
+	if want := image.Rect(4, 2, 6, 4); anim.Image[1].Rect != want {
+		t.Errorf("got changed frame %v, want %v", anim.Image[1].Rect, want)
+	}
+	if c := anim.Image[1].At(5, 3); c != (color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}) {
+		t.Errorf("got changed pixel %v, want white", c)
+	}
+	if anim.Delay[0] != 5 || anim.Delay[1] != 10 || anim.Delay[2] != 55 {
+		t.Errorf("got delays %v, want [5 10 55]", anim.Delay)
+	}
+	if anim.Disposal[1] != gif.DisposalBackground {
+		t.Errorf("got disposal %d before a resized frame, want %d", anim.Disposal[1], gif.DisposalBackground)
+	}
+}
+
+func TestWritePNG(t *testing.T) {
+	rec := New(Options{Scale: 3})
+	g := grid.New(2, 1)
+	g.Set(grid.Point{X: 1, Y: 0}, 9)
+	rec.Frame(g, Style{Max: 9})
+
+	var buf bytes.Buffer
+	if err := rec.WritePNG(&buf); err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if b := img.Bounds(); b.Dx() != 6 || b.Dy() != 3 {
+		t.Errorf("got %dx%d, want 6x3", b.Dx(), b.Dy())
+	}
+	heat := Palettes["heat"]
+	if img.At(0, 0) != heat[0] || img.At(5, 2) != heat[len(heat)-1] {
+		t.Errorf("got colors %v and %v, want the ends of the heat palette", img.At(0, 0), img.At(5, 2))
+	}
+}
+
+func TestIndex(t *testing.T) {
+	rec := New(Options{Palette: Palettes["gray"]})
+
+	tests := []struct {
+		v, max int
+		want   uint8
+	}{
+		{-1, 10, 0},
+		{0, 10, 0},
+		{5, 10, 8},
+		{10, 10, 15},
+		{11, 10, 15},
+		{1, 0, 15},
+	}
+	for _, test := range tests {
+		if got := rec.index(test.v, test.max); got != test.want {
+			t.Errorf("index(%d, %d) = %d, want %d", test.v, test.max, got, test.want)
+		}
+	}
+}
+
+func TestNilRecorder(t *testing.T) {
+	var rec *Recorder
+	rec.Frame(grid.New(1, 1), bits)
+
+	if rec.Len() != 0 {
+		t.Errorf("got %d frames, want 0", rec.Len())
+	}
+}
+
+func TestParsePalette(t *testing.T) {
+	p, err := ParsePalette("#000000, #ff8000,#FFFFFF")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(p) != 3 || p[1] != (color.RGBA{R: 0xff, G: 0x80, A: 0xff}) {
+		t.Errorf("got %v", p)
+	}
+
+	if p, err := ParsePalette("heat"); err != nil || len(p) != 16 {
+		t.Errorf("got %d colors and %v, want the heat palette", len(p), err)
+	}
+
+	for _, s := range []string{"", "nope", "#000000", "#000000,#12345", "#000000,#gggggg"} {
+		if _, err := ParsePalette(s); err == nil {
+			t.Errorf("ParsePalette(%q): got no error", s)
+		}
+	}
+}
@@ -0,0 +1,204 @@
+package viz
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/ryderlewis/aoc2021/pkg/grid"
+	"io"
+	"time"
+)
+
+// WatchOptions control how frames are drawn in the terminal
+type WatchOptions struct {
+	Delay time.Duration // time between frames. Defaults to 100ms.
+
+	// Keys, if set, are read a byte at a time to control the animation: space pauses and
+	// resumes, n steps a frame while paused, + and - change speed, and q stops drawing.
+	Keys io.Reader
+}
+
+// the limits on the time between frames, as changed by keys
+const (
+	minDelay = time.Millisecond
+	maxDelay = 10 * time.Second
+)
+
+// ANSI escape codes
+const (
+	clearScreen  = "\x1b[2J"
+	cursorHome   = "\x1b[H"
+	clearLine    = "\x1b[K"
+	clearBelow   = "\x1b[J"
+	highlightOn  = "\x1b[1;7m"
+	highlightOff = "\x1b[0m"
+)
+
+// Watch returns a recorder that draws each frame to w as it is recorded, redrawing the
+// screen with ANSI escape codes, rather than keeping the frames to save later
+func Watch(w io.Writer, opts WatchOptions) *Recorder {
+	if opts.Delay <= 0 {
+		opts.Delay = 100 * time.Millisecond
+	}
+
+	wt := &watcher{w: w, delay: opts.Delay}
+	if opts.Keys != nil {
+		wt.keys = readKeys(opts.Keys)
+	}
+
+	return &Recorder{watch: wt}
+}
+
+// watcher draws frames in the terminal, pausing between them
+type watcher struct {
+	w      io.Writer
+	delay  time.Duration
+	keys   <-chan byte // nil once there are no more keys
+	paused bool
+	quit   bool // whether to stop drawing frames
+	count  int
+
+	cur, prev *grid.Grid // the frame being shown, and the one before it
+	style     Style
+}
+
+// readKeys sends each byte read from r until it fails
+func readKeys(r io.Reader) <-chan byte {
+	keys := make(chan byte)
+	go func() {
+		defer close(keys)
+		buf := make([]byte, 1)
+		for {
+			n, err := r.Read(buf)
+			if n > 0 {
+				keys <- buf[0]
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	return keys
+}
+
+// frame draws g, then waits until it's time for the next frame
+func (w *watcher) frame(g *grid.Grid, style Style) {
+	if w.quit {
+		return
+	}
+
+	w.count++
+	w.prev, w.cur, w.style = w.cur, g.Copy(), style
+	w.draw()
+	w.wait()
+}
+
+// draw redraws the screen with the current frame, and a status line below it
+func (w *watcher) draw() {
+	var b bytes.Buffer
+	if w.count == 1 {
+		b.WriteString(clearScreen)
+	}
+	b.WriteString(cursorHome)
+	b.WriteString(render(w.cur, w.prev, w.style))
+
+	state := "playing"
+	if w.paused {
+		state = "paused"
+	}
+	fmt.Fprintf(&b, "frame %d, %v per frame, %s [space] pause [n] step [+/-] speed [q] quit%s\n", w.count, w.delay, state, clearLine)
+	b.WriteString(clearBelow)
+
+	w.w.Write(b.Bytes())
+}
+
+// wait returns once it's time for the next frame, handling any keys pressed meanwhile
+func (w *watcher) wait() {
+	timer := time.NewTimer(w.delay)
+	defer timer.Stop()
+
+	for {
+		var next <-chan time.Time
+		if !w.paused {
+			next = timer.C
+		}
+
+		select {
+		case <-next:
+			return
+		case k, ok := <-w.keys:
+			if !ok {
+				// nothing can resume the animation any more
+				w.keys, w.paused = nil, false
+				continue
+			}
+			switch k {
+			case ' ':
+				w.paused = !w.paused
+			case 'n', '.':
+				if w.paused {
+					return
+				}
+			case '+', '=':
+				if w.delay /= 2; w.delay < minDelay {
+					w.delay = minDelay
+				}
+			case '-', '_':
+				if w.delay *= 2; w.delay > maxDelay {
+					w.delay = maxDelay
+				}
+			case 'q', 'Q':
+				w.quit = true
+				return
+			default:
+				continue
+			}
+			w.draw()
+		}
+	}
+}
+
+// render draws g as text, one line per row, highlighting cells as the style asks. With no
+// Highlight in the style, the cells that differ from prev are highlighted, if prev is the
+// same size.
+func render(g, prev *grid.Grid, style Style) string {
+	var b bytes.Buffer
+	for y := 0; y < g.Height; y++ {
+		for x := 0; x < g.Width; x++ {
+			p := grid.Point{X: x, Y: y}
+			v := g.Get(p)
+
+			var lit bool
+			if style.Highlight != nil {
+				lit = style.Highlight(v)
+			} else if prev != nil && prev.Width == g.Width && prev.Height == g.Height {
+				lit = prev.Get(p) != v
+			}
+
+			if lit {
+				b.WriteString(highlightOn)
+			}
+			b.WriteByte(char(style.Chars, v))
+			if lit {
+				b.WriteString(highlightOff)
+			}
+		}
+		b.WriteString(clearLine)
+		b.WriteByte('\n')
+	}
+
+	return b.String()
+}
+
+// char returns the character for v, or '?' if there are none
+func char(chars string, v int) byte {
+	switch {
+	case len(chars) == 0:
+		return '?'
+	case v < 0:
+		return chars[0]
+	case v >= len(chars):
+		return chars[len(chars)-1]
+	}
+	return chars[v]
+}
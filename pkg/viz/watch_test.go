@@ -0,0 +1,97 @@
+package viz
+
+import (
+	"bytes"
+	"github.com/ryderlewis/aoc2021/pkg/grid"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRender(t *testing.T) {
+	prev := grid.New(3, 2)
+	g := prev.Copy()
+	g.Set(grid.Point{X: 1, Y: 0}, 1)
+	g.Set(grid.Point{X: 2, Y: 1}, 5)
+
+	got := render(g, prev, Style{Chars: ".#"})
+	want := "." + highlightOn + "#" + highlightOff + "." + clearLine + "\n" +
+		".." + highlightOn + "#" + highlightOff + clearLine + "\n"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	// a frame of a different size has nothing to compare with
+	if got := render(g, grid.New(2, 2), Style{Chars: ".#"}); strings.Contains(got, highlightOn) {
+		t.Errorf("got %q, want nothing highlighted", got)
+	}
+
+	hot := Style{Chars: "0123456789", Highlight: func(v int) bool { return v == 5 }}
+	want = "010" + clearLine + "\n" + "00" + highlightOn + "5" + highlightOff + clearLine + "\n"
+	if got := render(g, nil, hot); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	var out bytes.Buffer
+	rec := Watch(&out, WatchOptions{Delay: time.Millisecond})
+
+	g := grid.New(2, 1)
+	rec.Frame(g, bits)
+	g.Set(grid.Point{X: 0, Y: 0}, 1)
+	rec.Frame(g, bits)
+
+	if rec.Len() != 2 {
+		t.Errorf("got %d frames, want 2", rec.Len())
+	}
+	if n := strings.Count(out.String(), clearScreen); n != 1 {
+		t.Errorf("cleared the screen %d times, want 1", n)
+	}
+	if !strings.Contains(out.String(), highlightOn+"#"+highlightOff+".") {
+		t.Errorf("got %q, want the changed cell highlighted", out.String())
+	}
+	if err := rec.Save("watched.gif"); err == nil {
+		t.Error("saved a watched recording")
+	}
+}
+
+func TestWatchQuit(t *testing.T) {
+	keys, press := io.Pipe()
+	defer press.Close()
+	go press.Write([]byte("xq"))
+
+	// only quitting ends the first frame this soon
+	var out bytes.Buffer
+	rec := Watch(&out, WatchOptions{Delay: time.Hour, Keys: keys})
+	rec.Frame(grid.New(1, 1), bits)
+	rec.Frame(grid.New(1, 1), bits)
+
+	if rec.Len() != 1 {
+		t.Errorf("got %d frames, want 1", rec.Len())
+	}
+}
+
+func TestWatchStep(t *testing.T) {
+	keys, press := io.Pipe()
+	defer press.Close()
+
+	var out bytes.Buffer
+	rec := Watch(&out, WatchOptions{Delay: 4 * time.Second, Keys: keys})
+
+	// pause, step a frame, then slow down as far as it goes, speed up and step again
+	go press.Write([]byte(" n--+n"))
+	rec.Frame(grid.New(1, 1), bits)
+	rec.Frame(grid.New(1, 1), bits)
+
+	if rec.Len() != 2 {
+		t.Errorf("got %d frames, want 2", rec.Len())
+	}
+	if w := rec.watch; !w.paused || w.delay != maxDelay/2 {
+		t.Errorf("got paused %v with %v per frame, want paused with %v", w.paused, w.delay, maxDelay/2)
+	}
+	if !strings.Contains(out.String(), "paused") {
+		t.Errorf("got %q, want a paused status", out.String())
+	}
+}
@@ -0,0 +1,106 @@
+package day01
+
+import (
+	"github.com/ryderlewis/aoc2021/pkg/challenge"
+	"github.com/ryderlewis/aoc2021/pkg/parse"
+	"io"
+	"strconv"
+)
+
+// Puzzle is the list of depth measurements from the sonar sweep
+type Puzzle struct {
+	Depths []int
+}
+
+type Runner struct {
+	puzzle *Puzzle
+}
+
+var _ challenge.DailyChallenge = &Runner{}
+var _ challenge.PhasedChallenge = &Runner{}
+
+func init() {
+	challenge.Register(2021, 1, func() challenge.DailyChallenge {
+		return &Runner{}
+	})
+}
+
+func (r *Runner) Challenge1(input io.Reader) (string, error) {
+	if err := r.Load(input); err != nil {
+		return "", err
+	}
+
+	return r.Solve1()
+}
+
+func (r *Runner) Challenge2(input io.Reader) (string, error) {
+	if err := r.Load(input); err != nil {
+		return "", err
+	}
+
+	return r.Solve2()
+}
+
+func (r *Runner) Load(input io.Reader) (err error) {
+	r.puzzle, err = Parse(input)
+	return err
+}
+
+func (r *Runner) Solve1() (string, error) {
+	return Part1(r.puzzle)
+}
+
+func (r *Runner) Solve2() (string, error) {
+	return Part2(r.puzzle)
+}
+
+// Part1 counts the measurements that are deeper than the one before
+func Part1(p *Puzzle) (string, error) {
+	lastVal := -1
+	count := 0
+	for idx, val := range p.Depths {
+		if idx > 0 && val > lastVal {
+			count++
+		}
+		lastVal = val
+	}
+
+	return strconv.Itoa(count), nil
+}
+
+// Part2 counts the three-measurement sliding windows that are deeper than the one before
+func Part2(p *Puzzle) (string, error) {
+	sum := 0
+	count := 0
+	for idx, val := range p.Depths {
+		lastSum := sum
+		sum += val
+		if idx > 2 {
+			sum -= p.Depths[idx-3]
+			if sum > lastSum {
+				count++
+			}
+		}
+	}
+
+	return strconv.Itoa(count), nil
+}
+
+// Parse reads one depth measurement per line
+func Parse(input io.Reader) (*Puzzle, error) {
+	scanner := parse.NewScanner(input)
+	p := &Puzzle{Depths: make([]int, 0)}
+
+	for scanner.Scan() {
+		i, err := scanner.Field().Int()
+		if err != nil {
+			return nil, err
+		}
+		p.Depths = append(p.Depths, i)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
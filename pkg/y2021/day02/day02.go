@@ -0,0 +1,136 @@
+package day02
+
+import (
+	"fmt"
+	"github.com/ryderlewis/aoc2021/pkg/challenge"
+	"github.com/ryderlewis/aoc2021/pkg/parse"
+	"io"
+	"strconv"
+)
+
+// Command is a single step of the planned course, such as "forward 5"
+type Command struct {
+	Direction string
+	Value     int
+}
+
+// Puzzle is the planned course of the submarine
+type Puzzle struct {
+	Commands []Command
+}
+
+type Runner struct {
+	puzzle *Puzzle
+}
+
+var _ challenge.DailyChallenge = &Runner{}
+var _ challenge.PhasedChallenge = &Runner{}
+
+func init() {
+	challenge.Register(2021, 2, func() challenge.DailyChallenge {
+		return &Runner{}
+	})
+}
+
+func (r *Runner) Challenge1(input io.Reader) (string, error) {
+	if err := r.Load(input); err != nil {
+		return "", err
+	}
+
+	return r.Solve1()
+}
+
+func (r *Runner) Challenge2(input io.Reader) (string, error) {
+	if err := r.Load(input); err != nil {
+		return "", err
+	}
+
+	return r.Solve2()
+}
+
+func (r *Runner) Load(input io.Reader) (err error) {
+	r.puzzle, err = Parse(input)
+	return err
+}
+
+func (r *Runner) Solve1() (string, error) {
+	return Part1(r.puzzle)
+}
+
+func (r *Runner) Solve2() (string, error) {
+	return Part2(r.puzzle)
+}
+
+// Part1 follows the course, treating up and down as changes in depth
+func Part1(p *Puzzle) (string, error) {
+	h := 0
+	d := 0
+
+	for _, cmd := range p.Commands {
+		switch cmd.Direction {
+		case "forward":
+			h += cmd.Value
+		case "down":
+			d += cmd.Value
+		case "up":
+			d -= cmd.Value
+		default:
+			return "", fmt.Errorf("Invalid direction: %v", cmd.Direction)
+		}
+	}
+
+	return strconv.Itoa(h * d), nil
+}
+
+// Part2 follows the course, treating up and down as changes in aim
+func Part2(p *Puzzle) (string, error) {
+	h := 0
+	d := 0
+	a := 0
+
+	for _, cmd := range p.Commands {
+		switch cmd.Direction {
+		case "forward":
+			h += cmd.Value
+			d += a * cmd.Value
+		case "down":
+			a += cmd.Value
+		case "up":
+			a -= cmd.Value
+		default:
+			return "", fmt.Errorf("Invalid direction: %v", cmd.Direction)
+		}
+	}
+
+	return strconv.Itoa(h * d), nil
+}
+
+// Parse reads one command per line
+func Parse(input io.Reader) (*Puzzle, error) {
+	scanner := parse.NewScanner(input)
+	p := &Puzzle{Commands: make([]Command, 0)}
+
+	for scanner.Scan() {
+		tokens, err := scanner.Field().FieldsN(2)
+		if err != nil {
+			return nil, err
+		}
+
+		switch tokens[0].Text {
+		case "forward", "down", "up":
+		default:
+			return nil, tokens[0].Errorf("%w: want forward, down or up", parse.ErrSyntax)
+		}
+
+		i, err := tokens[1].Int()
+		if err != nil {
+			return nil, err
+		}
+		p.Commands = append(p.Commands, Command{Direction: tokens[0].Text, Value: i})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
@@ -0,0 +1,235 @@
+package day03
+
+import (
+	"fmt"
+	"github.com/ryderlewis/aoc2021/pkg/challenge"
+	"github.com/ryderlewis/aoc2021/pkg/parse"
+	"io"
+	"strconv"
+)
+
+// Puzzle is the diagnostic report, one binary number per entry
+type Puzzle struct {
+	Report []string
+}
+
+type Runner struct {
+	puzzle *Puzzle
+}
+
+var _ challenge.DailyChallenge = &Runner{}
+var _ challenge.PhasedChallenge = &Runner{}
+var _ challenge.Explainer = &Runner{}
+
+func init() {
+	challenge.Register(2021, 3, func() challenge.DailyChallenge {
+		return &Runner{}
+	})
+}
+
+func (r *Runner) Challenge1(input io.Reader) (string, error) {
+	if err := r.Load(input); err != nil {
+		return "", err
+	}
+
+	return r.Solve1()
+}
+
+func (r *Runner) Challenge2(input io.Reader) (string, error) {
+	if err := r.Load(input); err != nil {
+		return "", err
+	}
+
+	return r.Solve2()
+}
+
+func (r *Runner) Challenge1Explain(input io.Reader, e *challenge.Explanation) (string, error) {
+	if err := r.Load(input); err != nil {
+		return "", err
+	}
+
+	return part1(r.puzzle, e)
+}
+
+func (r *Runner) Challenge2Explain(input io.Reader, e *challenge.Explanation) (string, error) {
+	if err := r.Load(input); err != nil {
+		return "", err
+	}
+
+	return part2(r.puzzle, e)
+}
+
+func (r *Runner) Load(input io.Reader) (err error) {
+	r.puzzle, err = Parse(input)
+	return err
+}
+
+func (r *Runner) Solve1() (string, error) {
+	return Part1(r.puzzle)
+}
+
+func (r *Runner) Solve2() (string, error) {
+	return Part2(r.puzzle)
+}
+
+// Part1 calculates the power consumption from the gamma and epsilon rates
+func Part1(p *Puzzle) (string, error) {
+	return part1(p, nil)
+}
+
+func part1(p *Puzzle, e *challenge.Explanation) (string, error) {
+	gamma := make([]int, len(p.Report[0]))
+
+	for _, s := range p.Report {
+		for j, c := range s {
+			if c == '1' {
+				gamma[j] += 1
+			}
+		}
+	}
+
+	gsum := 0
+	esum := 0
+
+	for i := 0; i < len(gamma); i++ {
+		power := len(gamma) - i - 1
+
+		if gamma[i] > len(p.Report)/2 {
+			gsum += 1 << power
+			e.Step("Bit %d: %d of %d numbers have a 1, so gamma has a 1 and epsilon a 0", i, gamma[i], len(p.Report))
+		} else {
+			esum += 1 << power
+			e.Step("Bit %d: %d of %d numbers have a 1, so gamma has a 0 and epsilon a 1", i, gamma[i], len(p.Report))
+		}
+	}
+
+	e.Step("Gamma is %0*b (%d) and epsilon is %0*b (%d), so the power consumption is %d",
+		len(gamma), gsum, gsum, len(gamma), esum, esum, gsum*esum)
+	return strconv.Itoa(gsum * esum), nil
+}
+
+// Part2 calculates the life support rating from the oxygen and CO2 ratings
+func Part2(p *Puzzle) (string, error) {
+	return part2(p, nil)
+}
+
+func part2(p *Puzzle, e *challenge.Explanation) (string, error) {
+	// split the inputs into oxygen and co2 sets
+	oxygen := make([]string, len(p.Report))
+	copy(oxygen, p.Report)
+	for pos := 0; len(oxygen) > 1 && pos < len(oxygen[0]); pos++ {
+		newOxygen := make([]string, 0)
+		g := gamma(oxygen, pos)
+		for _, s := range oxygen {
+			if s[pos] == strconv.Itoa(g)[0] {
+				newOxygen = append(newOxygen, s)
+			}
+		}
+		listed(e.Step("Oxygen, bit %d: %d has the most, keeping %d of %d numbers", pos, g, len(newOxygen), len(oxygen)), newOxygen)
+		oxygen = newOxygen
+	}
+
+	co2 := make([]string, len(p.Report))
+	copy(co2, p.Report)
+	for pos := 0; len(co2) > 1 && pos < len(co2[0]); pos++ {
+		newCo2 := make([]string, 0)
+		least := epsilon(co2, pos)
+		for _, s := range co2 {
+			if s[pos] == strconv.Itoa(least)[0] {
+				newCo2 = append(newCo2, s)
+			}
+		}
+		// if every number has the same bit here, there is no less common value to keep
+		if len(newCo2) > 0 {
+			listed(e.Step("CO2, bit %d: %d has the fewest, keeping %d of %d numbers", pos, least, len(newCo2), len(co2)), newCo2)
+			co2 = newCo2
+		} else {
+			e.Step("CO2, bit %d: every number has the same bit, keeping all %d", pos, len(co2))
+		}
+	}
+
+	o, _ := strconv.ParseInt(oxygen[0], 2, 64)
+	c, _ := strconv.ParseInt(co2[0], 2, 64)
+	e.Step("The oxygen generator rating is %s (%d) and the CO2 scrubber rating is %s (%d), so the life support rating is %d",
+		oxygen[0], o, co2[0], c, o*c)
+	return fmt.Sprintf("%d", o*c), nil
+}
+
+// maxListed is the most numbers listed in a step of the explanation
+const maxListed = 8
+
+// listed adds the numbers to step, if there are few enough to read
+func listed(step *challenge.Step, numbers []string) {
+	if len(numbers) > maxListed {
+		return
+	}
+	for _, n := range numbers {
+		step.Detail("%s", n)
+	}
+}
+
+func gamma(inputs []string, index int) int {
+	v := mostCommon(inputs, index)
+	if v < 0 {
+		return 1
+	}
+	return v
+}
+
+func epsilon(inputs []string, index int) int {
+	v := mostCommon(inputs, index)
+	switch v {
+	case 1:
+		return 0
+	case 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func mostCommon(inputs []string, index int) int {
+	count := 0
+
+	for _, s := range inputs {
+		if s[index] == '1' {
+			count += 1
+		}
+	}
+
+	if len(inputs)%2 == 0 && count == len(inputs)/2 {
+		return -1
+	} else if count > len(inputs)/2 {
+		return 1
+	}
+
+	return 0
+}
+
+// Parse reads one binary number per line. Every number must have the same number of bits.
+func Parse(input io.Reader) (*Puzzle, error) {
+	scanner := parse.NewScanner(input)
+	p := &Puzzle{Report: make([]string, 0)}
+
+	for scanner.Scan() {
+		line := scanner.Field()
+		if err := line.Only("01"); err != nil {
+			return nil, err
+		}
+		if len(line.Text) == 0 || (len(p.Report) > 0 && len(line.Text) != len(p.Report[0])) {
+			return nil, scanner.Errorf("%w: want a binary number the same length as the first", parse.ErrSyntax)
+		}
+		if len(line.Text) > 62 {
+			return nil, line.Errorf("%w: more than 62 bits", parse.ErrInteger)
+		}
+		p.Report = append(p.Report, line.Text)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(p.Report) == 0 {
+		return nil, scanner.Truncated("a binary number")
+	}
+	return p, nil
+}
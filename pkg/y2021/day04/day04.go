@@ -0,0 +1,266 @@
+package day04
+
+import (
+	"fmt"
+	"github.com/ryderlewis/aoc2021/pkg/challenge"
+	"github.com/ryderlewis/aoc2021/pkg/parse"
+	"github.com/ryderlewis/aoc2021/pkg/trace"
+	"io"
+	"strconv"
+)
+
+type Board struct {
+	valuePositions map[int]int
+	valueCalled    [25]bool
+}
+
+// Puzzle is the order numbers are drawn in, and the bingo boards being played
+type Puzzle struct {
+	Numbers []int
+	Boards  []*Board
+}
+
+type Runner struct {
+	puzzle *Puzzle
+	trace  *trace.Tracer
+}
+
+var _ challenge.DailyChallenge = &Runner{}
+var _ challenge.PhasedChallenge = &Runner{}
+var _ trace.Traced = &Runner{}
+
+func init() {
+	challenge.Register(2021, 4, func() challenge.DailyChallenge {
+		return &Runner{}
+	})
+}
+
+func (r *Runner) Challenge1(input io.Reader) (string, error) {
+	if err := r.Load(input); err != nil {
+		return "", err
+	}
+
+	return r.Solve1()
+}
+
+func (r *Runner) Challenge2(input io.Reader) (string, error) {
+	if err := r.Load(input); err != nil {
+		return "", err
+	}
+
+	return r.Solve2()
+}
+
+func (r *Runner) Load(input io.Reader) (err error) {
+	r.puzzle, err = Parse(input)
+	return err
+}
+
+func (r *Runner) SetTracer(t *trace.Tracer) {
+	r.trace = t
+}
+
+func (r *Runner) Solve1() (string, error) {
+	return part1(r.puzzle, r.trace)
+}
+
+func (r *Runner) Solve2() (string, error) {
+	return part2(r.puzzle, r.trace)
+}
+
+// Part1 scores the first board to win
+func Part1(p *Puzzle) (string, error) {
+	return part1(p, nil)
+}
+
+// Part2 scores the last board to win
+func Part2(p *Puzzle) (string, error) {
+	return part2(p, nil)
+}
+
+func part1(p *Puzzle, t *trace.Tracer) (string, error) {
+	boards := p.newGame()
+
+	for _, val := range p.Numbers {
+		t.Debugf("Calling %d", val)
+		for i, b := range boards {
+			if b.Call(val) && b.Winner() {
+				sum := b.UncalledSum()
+				t.Infof("Board %d wins on %d, with %d uncalled", i+1, val, sum)
+				return strconv.Itoa(val * sum), nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("No winner")
+}
+
+func part2(p *Puzzle, t *trace.Tracer) (string, error) {
+	boards := p.newGame()
+
+	lastWin := 0
+	for _, val := range p.Numbers {
+		t.Debugf("Calling %d", val)
+		for i, b := range boards {
+			if !b.Winner() {
+				if b.Call(val) && b.Winner() {
+					sum := b.UncalledSum()
+					t.Infof("Board %d wins on %d, with %d uncalled", i+1, val, sum)
+					lastWin = val * sum
+				}
+			}
+		}
+	}
+
+	return strconv.Itoa(lastWin), nil
+}
+
+// newGame returns copies of the puzzle's boards that can be marked without changing the puzzle
+func (p *Puzzle) newGame() []*Board {
+	boards := make([]*Board, len(p.Boards))
+	for i, b := range p.Boards {
+		c := *b
+		boards[i] = &c
+	}
+
+	return boards
+}
+
+// Parse reads the drawn numbers from the first line, followed by blank-line separated boards
+func Parse(input io.Reader) (*Puzzle, error) {
+	scanner := parse.NewScanner(input)
+	p := &Puzzle{
+		Numbers: make([]int, 0),
+		Boards:  make([]*Board, 0),
+	}
+
+	line, err := scanner.Next()
+	if err != nil {
+		return nil, err
+	}
+	if p.Numbers, err = line.Ints(","); err != nil {
+		return nil, err
+	}
+
+	for scanner.Scan() {
+		if scanner.Text() != "" {
+			return nil, scanner.Field().Errorf("%w: want a blank line", parse.ErrSyntax)
+		}
+
+		board := &Board{
+			valuePositions: make(map[int]int),
+		}
+		p.Boards = append(p.Boards, board)
+
+		for row := 0; row < 5; row++ {
+			line, err := scanner.Next()
+			if err != nil {
+				return nil, err
+			}
+			nums, err := line.FieldsN(5)
+			if err != nil {
+				return nil, err
+			}
+
+			for col := 0; col < 5; col++ {
+				i, err := nums[col].Int()
+				if err != nil {
+					return nil, err
+				}
+				if _, dup := board.valuePositions[i]; dup {
+					return nil, nums[col].Errorf("%w: number already on this board", parse.ErrSyntax)
+				}
+				board.valuePositions[i] = row*5 + col
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (b *Board) Call(num int) bool {
+	if pos, ok := b.valuePositions[num]; ok {
+		b.valueCalled[pos] = true
+		return true
+	}
+
+	return false
+}
+
+func (b *Board) Winner() bool {
+	// check horizontals
+	for row := 0; row < 5; row++ {
+		allTrue := true
+		for col := 0; col < 5; col++ {
+			if !b.valueCalled[row*5+col] {
+				allTrue = false
+				break
+			}
+		}
+
+		if allTrue {
+			return true
+		}
+	}
+
+	// check verticals
+	for col := 0; col < 5; col++ {
+		allTrue := true
+		for row := 0; row < 5; row++ {
+			if !b.valueCalled[row*5+col] {
+				allTrue = false
+				break
+			}
+		}
+
+		if allTrue {
+			return true
+		}
+	}
+
+	// check diagnals
+	/*
+	allTrue := true
+	for row := 0; row < 5; row++ {
+		col := row
+		if !b.valueCalled[row*5+col] {
+			allTrue = false
+			break
+		}
+	}
+	if allTrue {
+		fmt.Printf("Ryder C\n")
+		return true
+	}
+
+	allTrue = true
+	for row := 0; row < 5; row++ {
+		col := 4 - row
+		if !b.valueCalled[row*5+col] {
+			allTrue = false
+			break
+		}
+	}
+	if allTrue {
+		fmt.Printf("Ryder D\n")
+		return true
+	}
+	
+	 */
+
+	return false
+}
+
+func (b *Board) UncalledSum() int {
+	sum := 0
+	for val, pos := range b.valuePositions {
+		if !b.valueCalled[pos] {
+			sum += val
+		}
+	}
+
+	return sum
+}
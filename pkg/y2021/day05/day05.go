@@ -0,0 +1,169 @@
+package day05
+
+import (
+	"github.com/ryderlewis/aoc2021/pkg/challenge"
+	"github.com/ryderlewis/aoc2021/pkg/grid"
+	"github.com/ryderlewis/aoc2021/pkg/parse"
+	"io"
+	"regexp"
+	"strconv"
+)
+
+// Line is a line of hydrothermal vents, running from (X1, Y1) to (X2, Y2)
+type Line struct {
+	X1, Y1, X2, Y2 int
+}
+
+// Puzzle is the list of vent lines
+type Puzzle struct {
+	Lines []*Line
+}
+
+type Runner struct {
+	puzzle *Puzzle
+}
+
+var _ challenge.DailyChallenge = &Runner{}
+var _ challenge.PhasedChallenge = &Runner{}
+
+func init() {
+	challenge.Register(2021, 5, func() challenge.DailyChallenge {
+		return &Runner{}
+	})
+}
+
+func maxInt(vals ...int) int {
+	m := vals[0]
+	for _, x := range vals {
+		if x > m {
+			m = x
+		}
+	}
+
+	return m
+}
+
+func (r *Runner) Challenge1(input io.Reader) (string, error) {
+	if err := r.Load(input); err != nil {
+		return "", err
+	}
+
+	return r.Solve1()
+}
+
+func (r *Runner) Challenge2(input io.Reader) (string, error) {
+	if err := r.Load(input); err != nil {
+		return "", err
+	}
+
+	return r.Solve2()
+}
+
+func (r *Runner) Load(input io.Reader) (err error) {
+	r.puzzle, err = Parse(input)
+	return err
+}
+
+func (r *Runner) Solve1() (string, error) {
+	return Part1(r.puzzle)
+}
+
+func (r *Runner) Solve2() (string, error) {
+	return Part2(r.puzzle)
+}
+
+// Part1 counts the points where at least two horizontal or vertical lines overlap
+func Part1(p *Puzzle) (string, error) {
+	return strconv.Itoa(p.overlaps(false)), nil
+}
+
+// Part2 counts the points where at least two lines overlap, including diagonals
+func Part2(p *Puzzle) (string, error) {
+	return strconv.Itoa(p.overlaps(true)), nil
+}
+
+// overlaps draws the lines on the ocean floor, and counts the points covered by more
+// than one of them. Diagonal lines are skipped unless diagonals is set.
+func (p *Puzzle) overlaps(diagonals bool) int {
+	maxVal := 0
+	for _, line := range p.Lines {
+		maxVal = maxInt(line.X1, line.Y1, line.X2, line.Y2, maxVal)
+	}
+	floor := grid.New(maxVal+1, maxVal+1)
+
+	for _, line := range p.Lines {
+		if line.X1 != line.X2 && line.Y1 != line.Y2 && !diagonals {
+			continue
+		}
+
+		step := grid.Point{X: sign(line.X2 - line.X1), Y: sign(line.Y2 - line.Y1)}
+		end := grid.Point{X: line.X2, Y: line.Y2}
+		for pos := (grid.Point{X: line.X1, Y: line.Y1}); ; pos = pos.Add(step) {
+			floor.Add(pos, 1)
+			if pos == end {
+				break
+			}
+		}
+	}
+
+	return floor.Count(func(v int) bool {
+		return v > 1
+	})
+}
+
+func sign(i int) int {
+	switch {
+	case i < 0:
+		return -1
+	case i > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// maxCoordinate is the furthest a vent can be, which keeps the ocean floor small
+const maxCoordinate = 2000
+
+var lineSyntax = regexp.MustCompile(`^(\d+),(\d+) -> (\d+),(\d+)$`)
+
+// Parse reads one line per row, in the form "x1,y1 -> x2,y2". Lines must be horizontal,
+// vertical or at 45 degrees, and are normalized so that they run left to right, or top
+// to bottom for vertical lines. Diagonals going up to the right keep running upwards.
+func Parse(input io.Reader) (*Puzzle, error) {
+	scanner := parse.NewScanner(input)
+	p := &Puzzle{Lines: make([]*Line, 0)}
+
+	for scanner.Scan() {
+		fields, err := scanner.Field().Match(lineSyntax, `"x1,y1 -> x2,y2"`)
+		if err != nil {
+			return nil, err
+		}
+
+		var coords [4]int
+		for i, f := range fields {
+			if coords[i], err = f.Int(); err != nil {
+				return nil, err
+			}
+			if coords[i] > maxCoordinate {
+				return nil, f.Errorf("%w: more than %d", parse.ErrInteger, maxCoordinate)
+			}
+		}
+		line := &Line{X1: coords[0], Y1: coords[1], X2: coords[2], Y2: coords[3]}
+		if dx, dy := line.X2-line.X1, line.Y2-line.Y1; dx != 0 && dy != 0 && dx != dy && dx != -dy {
+			return nil, scanner.Errorf("%w: want a horizontal, vertical or 45 degree line", parse.ErrSyntax)
+		}
+
+		if line.X1 > line.X2 || (line.X1 == line.X2 && line.Y1 > line.Y2) {
+			line.X1, line.X2 = line.X2, line.X1
+			line.Y1, line.Y2 = line.Y2, line.Y1
+		}
+
+		p.Lines = append(p.Lines, line)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
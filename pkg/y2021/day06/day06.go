@@ -0,0 +1,134 @@
+package day06
+
+import (
+	"github.com/ryderlewis/aoc2021/pkg/challenge"
+	"github.com/ryderlewis/aoc2021/pkg/parse"
+	"io"
+	"strconv"
+)
+
+// Puzzle is the internal timer of each lanternfish in the school
+type Puzzle struct {
+	Timers []int
+}
+
+type Runner struct {
+	puzzle *Puzzle
+}
+
+var _ challenge.DailyChallenge = &Runner{}
+var _ challenge.PhasedChallenge = &Runner{}
+
+func init() {
+	challenge.Register(2021, 6, func() challenge.DailyChallenge {
+		return &Runner{}
+	})
+}
+
+func (r *Runner) Challenge1(input io.Reader) (string, error) {
+	if err := r.Load(input); err != nil {
+		return "", err
+	}
+
+	return r.Solve1()
+}
+
+func (r *Runner) Challenge2(input io.Reader) (string, error) {
+	if err := r.Load(input); err != nil {
+		return "", err
+	}
+
+	return r.Solve2()
+}
+
+func (r *Runner) Load(input io.Reader) (err error) {
+	r.puzzle, err = Parse(input)
+	return err
+}
+
+func (r *Runner) Solve1() (string, error) {
+	return Part1(r.puzzle)
+}
+
+func (r *Runner) Solve2() (string, error) {
+	return Part2(r.puzzle)
+}
+
+// Part1 counts the lanternfish after 80 days
+func Part1(p *Puzzle) (string, error) {
+	return strconv.Itoa(Count(p.Timers, 80)), nil
+}
+
+// Part2 counts the lanternfish after 256 days
+func Part2(p *Puzzle) (string, error) {
+	return strconv.Itoa(Count(p.Timers, 256)), nil
+}
+
+// Count returns the number of fish there will be after the given number of days
+func Count(timers []int, days int) int {
+	s := &school{}
+	if days >= 0 {
+		s.memo = make([]int, days+1)
+	}
+
+	sum := 0
+	for _, f := range timers {
+		sum += s.calcMemo(days - f)
+	}
+
+	return sum
+}
+
+// school remembers how many fish a single fish turns into over a number of days, for
+// every number of days up to the most it is asked about
+type school struct {
+	memo []int
+}
+
+func (s *school) calcMemo(days int) int {
+	if days < 0 {
+		return 1
+	}
+
+	if s.memo[days] != 0 {
+		return s.memo[days]
+	}
+
+	if days == 0 {
+		s.memo[days] = 1
+		return 1
+	}
+
+	fishCount := 1 // count the current fish
+	for spawnDay := days - 1; spawnDay >= 0; spawnDay -= 7 {
+		// count the fish that this new fish spawns
+		fishCount += s.calcMemo(spawnDay - 8)
+	}
+
+	s.memo[days] = fishCount
+	return fishCount
+}
+
+// Parse reads the comma separated timers, each from 0 to 8
+func Parse(input io.Reader) (*Puzzle, error) {
+	p := &Puzzle{Timers: make([]int, 0)}
+
+	scanner := parse.NewScanner(input)
+	for scanner.Scan() {
+		for _, f := range scanner.Field().Split(",") {
+			i, err := f.Int()
+			if err != nil {
+				return nil, err
+			}
+			if i < 0 || i > 8 {
+				return nil, f.Errorf("%w: want a timer from 0 to 8", parse.ErrInteger)
+			}
+			p.Timers = append(p.Timers, i)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
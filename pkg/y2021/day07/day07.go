@@ -0,0 +1,138 @@
+package day07
+
+import (
+	"github.com/ryderlewis/aoc2021/pkg/challenge"
+	"github.com/ryderlewis/aoc2021/pkg/parse"
+	"io"
+	"sort"
+	"strconv"
+)
+
+// maxPosition is the furthest a crab can be, which keeps the fuel table in part 2 small
+const maxPosition = 100000
+
+// Puzzle is the horizontal position of each crab submarine
+type Puzzle struct {
+	Crabs []int
+}
+
+type Runner struct {
+	puzzle *Puzzle
+}
+
+var _ challenge.DailyChallenge = &Runner{}
+var _ challenge.PhasedChallenge = &Runner{}
+
+func init() {
+	challenge.Register(2021, 7, func() challenge.DailyChallenge {
+		return &Runner{}
+	})
+}
+
+func (r *Runner) Challenge1(input io.Reader) (string, error) {
+	if err := r.Load(input); err != nil {
+		return "", err
+	}
+
+	return r.Solve1()
+}
+
+func (r *Runner) Challenge2(input io.Reader) (string, error) {
+	if err := r.Load(input); err != nil {
+		return "", err
+	}
+
+	return r.Solve2()
+}
+
+func (r *Runner) Load(input io.Reader) (err error) {
+	r.puzzle, err = Parse(input)
+	return err
+}
+
+func (r *Runner) Solve1() (string, error) {
+	return Part1(r.puzzle)
+}
+
+func (r *Runner) Solve2() (string, error) {
+	return Part2(r.puzzle)
+}
+
+// Part1 finds the least fuel needed to align, where each step costs one fuel
+func Part1(p *Puzzle) (string, error) {
+	crabs := p.sorted()
+	median := crabs[len(crabs)/2]
+
+	dist := 0
+	for _, c := range crabs {
+		if median > c {
+			dist += median - c
+		} else {
+			dist += c - median
+		}
+	}
+
+	return strconv.Itoa(dist), nil
+}
+
+// Part2 finds the least fuel needed to align, where each step costs one more than the last
+func Part2(p *Puzzle) (string, error) {
+	crabs := p.sorted()
+	fuel := make([]int, crabs[len(crabs)-1]+1)
+	for i := 1; i < len(fuel); i++ {
+		fuel[i] = i + fuel[i-1]
+	}
+
+	var bestFuel int
+	for loc := crabs[0]; loc <= crabs[len(crabs)-1]; loc++ {
+		f := 0
+		for _, c := range crabs {
+			if c < loc {
+				f += fuel[loc-c]
+			} else {
+				f += fuel[c-loc]
+			}
+		}
+
+		if loc == crabs[0] || f < bestFuel {
+			bestFuel = f
+		}
+	}
+
+	return strconv.Itoa(bestFuel), nil
+}
+
+// sorted returns a sorted copy of the crab positions
+func (p *Puzzle) sorted() []int {
+	crabs := make([]int, len(p.Crabs))
+	copy(crabs, p.Crabs)
+	sort.Ints(crabs)
+
+	return crabs
+}
+
+// Parse reads the comma separated crab positions, each from 0 to 100000
+func Parse(input io.Reader) (*Puzzle, error) {
+	p := &Puzzle{Crabs: make([]int, 0)}
+	scanner := parse.NewScanner(input)
+	for scanner.Scan() {
+		for _, f := range scanner.Field().Split(",") {
+			crab, err := f.Int()
+			if err != nil {
+				return nil, err
+			}
+			if crab < 0 || crab > maxPosition {
+				return nil, f.Errorf("%w: want a position from 0 to %d", parse.ErrInteger, maxPosition)
+			}
+			p.Crabs = append(p.Crabs, crab)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(p.Crabs) == 0 {
+		return nil, scanner.Truncated("a crab position")
+	}
+	return p, nil
+}
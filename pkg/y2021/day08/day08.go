@@ -0,0 +1,296 @@
+package day08
+
+import (
+	"fmt"
+	"github.com/ryderlewis/aoc2021/pkg/challenge"
+	"github.com/ryderlewis/aoc2021/pkg/parse"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Entry is one display's ten unique signal patterns and its four digit output value
+type Entry struct {
+	Patterns []string
+	Outputs  []string
+}
+
+// Puzzle is the list of notes taken on each display
+type Puzzle struct {
+	Entries []Entry
+}
+
+type Runner struct {
+	puzzle *Puzzle
+}
+
+var _ challenge.DailyChallenge = &Runner{}
+var _ challenge.PhasedChallenge = &Runner{}
+var _ challenge.Explainer = &Runner{}
+
+func init() {
+	challenge.Register(2021, 8, func() challenge.DailyChallenge {
+		return &Runner{}
+	})
+}
+
+func (r *Runner) Challenge1(input io.Reader) (string, error) {
+	if err := r.Load(input); err != nil {
+		return "", err
+	}
+
+	return r.Solve1()
+}
+
+func (r *Runner) Challenge2(input io.Reader) (string, error) {
+	if err := r.Load(input); err != nil {
+		return "", err
+	}
+
+	return r.Solve2()
+}
+
+func (r *Runner) Challenge1Explain(input io.Reader, e *challenge.Explanation) (string, error) {
+	if err := r.Load(input); err != nil {
+		return "", err
+	}
+
+	return part1(r.puzzle, e)
+}
+
+func (r *Runner) Challenge2Explain(input io.Reader, e *challenge.Explanation) (string, error) {
+	if err := r.Load(input); err != nil {
+		return "", err
+	}
+
+	return part2(r.puzzle, e)
+}
+
+func (r *Runner) Load(input io.Reader) (err error) {
+	r.puzzle, err = Parse(input)
+	return err
+}
+
+func (r *Runner) Solve1() (string, error) {
+	return Part1(r.puzzle)
+}
+
+func (r *Runner) Solve2() (string, error) {
+	return Part2(r.puzzle)
+}
+
+// Part1 counts the output digits that use a unique number of segments
+func Part1(p *Puzzle) (string, error) {
+	return part1(p, nil)
+}
+
+func part1(p *Puzzle, e *challenge.Explanation) (string, error) {
+	count := 0
+	for i, entry := range p.Entries {
+		before := count
+		for _, o := range entry.Outputs {
+			switch len(o) {
+			case 2:
+				count++
+			case 3:
+				count++
+			case 4:
+				count++
+			case 7:
+				count++
+			}
+		}
+		e.Step("Entry %d: %d of the outputs %s light 2, 3, 4 or 7 segments, for a 1, 7, 4 or 8",
+			i+1, count-before, strings.Join(entry.Outputs, " "))
+	}
+	return strconv.Itoa(count), nil
+}
+
+// Part2 deduces the wiring of every display and sums the decoded output values
+func Part2(p *Puzzle) (string, error) {
+	return part2(p, nil)
+}
+
+func part2(p *Puzzle, e *challenge.Explanation) (string, error) {
+	digits := map[int]string{
+		0: "abcefg",
+		1: "cf",
+		2: "acdeg",
+		3: "acdfg",
+		4: "bcdf",
+		5: "abdfg",
+		6: "abdefg",
+		7: "acf",
+		8: "abcdefg",
+		9: "abcdfg",
+	}
+	revDigits := make(map[string]int)
+	for i, s := range digits {
+		revDigits[s] = i
+	}
+
+	mappings := make([]map[rune]rune, 0)
+	runes := "abcdefg"
+	for _, perm := range permutations(runes) {
+		m := make(map[rune]rune)
+		for i := 0; i < len(runes); i++ {
+			m[rune(runes[i])] = rune(perm[i])
+		}
+		mappings = append(mappings, m)
+	}
+
+	sum := 0
+	for n, entry := range p.Entries {
+		// find a coherent mapping
+		decoded := false
+		for _, m := range mappings {
+			isGood := true
+
+			for _, pi := range entry.Patterns {
+				mappedInput := SortString(strings.Map(func(r rune) rune {
+					return m[r]
+				}, pi))
+
+				if _, ok := revDigits[mappedInput]; !ok {
+					isGood = false
+					break
+				}
+			}
+
+			if !isGood {
+				continue
+			}
+
+			value := 0
+			for _, o := range entry.Outputs {
+				mappedOutput := SortString(strings.Map(func(r rune) rune {
+					return m[r]
+				}, o))
+				digit, ok := revDigits[mappedOutput]
+				if !ok {
+					isGood = false
+					break
+				}
+				value = value*10 + digit
+			}
+
+			if isGood {
+				sum += value
+				decoded = true
+
+				step := e.Step("Entry %d: the output %s reads %04d", n+1, strings.Join(entry.Outputs, " "), value)
+				for _, wire := range runes {
+					step.Detail("wire %c drives segment %c", wire, m[wire])
+				}
+				break
+			}
+		}
+		if !decoded {
+			return "", fmt.Errorf("No wiring decodes entry %d", n+1)
+		}
+	}
+
+	return strconv.Itoa(sum), nil
+}
+
+// Parse reads one entry per line, with ten patterns and four outputs separated by " | "
+func Parse(input io.Reader) (*Puzzle, error) {
+	scanner := parse.NewScanner(input)
+	p := &Puzzle{Entries: make([]Entry, 0)}
+
+	for scanner.Scan() {
+		parts, err := scanner.Field().SplitN(" | ", 2)
+		if err != nil {
+			return nil, err
+		}
+
+		patterns, err := segments(parts[0], 10)
+		if err != nil {
+			return nil, err
+		}
+		outputs, err := segments(parts[1], 4)
+		if err != nil {
+			return nil, err
+		}
+
+		p.Entries = append(p.Entries, Entry{
+			Patterns: patterns,
+			Outputs:  outputs,
+		})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// segments reads n space separated sets of lit segments, each using the letters a to g
+// at most once
+func segments(f parse.Field, n int) ([]string, error) {
+	fields, err := f.FieldsN(n)
+	if err != nil {
+		return nil, err
+	}
+
+	sets := make([]string, n)
+	for i, field := range fields {
+		if err := field.Only("abcdefg"); err != nil {
+			return nil, err
+		}
+		for j := 1; j < len(field.Text); j++ {
+			if strings.IndexByte(field.Text[:j], field.Text[j]) >= 0 {
+				return nil, field.Slice(j, j+1).Errorf("%w: segment %c is lit twice", parse.ErrCharacter, field.Text[j])
+			}
+		}
+		sets[i] = field.Text
+	}
+
+	return sets, nil
+}
+
+func join(ins []rune, c rune) (result []string) {
+	for i := 0; i <= len(ins); i++ {
+		result = append(result, string(ins[:i])+string(c)+string(ins[i:]))
+	}
+	return
+}
+
+func permutations(testStr string) []string {
+	var n func(testStr []rune, p []string) []string
+	n = func(testStr []rune, p []string) []string {
+		if len(testStr) == 0 {
+			return p
+		} else {
+			result := []string{}
+			for _, e := range p {
+				result = append(result, join([]rune(e), testStr[0])...)
+			}
+			return n(testStr[1:], result)
+		}
+	}
+
+	output := []rune(testStr)
+	return n(output[1:], []string{string(output[0])})
+}
+
+type sortRunes []rune
+
+func (s sortRunes) Less(i, j int) bool {
+	return s[i] < s[j]
+}
+
+func (s sortRunes) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+
+func (s sortRunes) Len() int {
+	return len(s)
+}
+
+func SortString(s string) string {
+	r := []rune(s)
+	sort.Sort(sortRunes(r))
+	return string(r)
+}
@@ -0,0 +1,133 @@
+package day09
+
+import (
+	"fmt"
+	"github.com/ryderlewis/aoc2021/pkg/challenge"
+	"github.com/ryderlewis/aoc2021/pkg/grid"
+	"github.com/ryderlewis/aoc2021/pkg/parse"
+	"github.com/ryderlewis/aoc2021/pkg/trace"
+	"io"
+	"sort"
+	"strconv"
+)
+
+// Puzzle is the heightmap of the cave floor
+type Puzzle struct {
+	Heights *grid.Grid
+}
+
+type Runner struct {
+	puzzle *Puzzle
+	trace  *trace.Tracer
+}
+
+var _ challenge.DailyChallenge = &Runner{}
+var _ challenge.PhasedChallenge = &Runner{}
+var _ trace.Traced = &Runner{}
+
+func init() {
+	challenge.Register(2021, 9, func() challenge.DailyChallenge {
+		return &Runner{}
+	})
+}
+
+func (r *Runner) Challenge1(input io.Reader) (string, error) {
+	if err := r.Load(input); err != nil {
+		return "", err
+	}
+
+	return r.Solve1()
+}
+
+func (r *Runner) Challenge2(input io.Reader) (string, error) {
+	if err := r.Load(input); err != nil {
+		return "", err
+	}
+
+	return r.Solve2()
+}
+
+func (r *Runner) Load(input io.Reader) (err error) {
+	r.puzzle, err = Parse(input)
+	return err
+}
+
+func (r *Runner) SetTracer(t *trace.Tracer) {
+	r.trace = t
+}
+
+func (r *Runner) Solve1() (string, error) {
+	return Part1(r.puzzle)
+}
+
+func (r *Runner) Solve2() (string, error) {
+	return part2(r.puzzle, r.trace)
+}
+
+// Part1 sums the risk levels of the low points
+func Part1(p *Puzzle) (string, error) {
+	risk := 0
+	for _, low := range p.lowPoints() {
+		risk += p.Heights.Get(low) + 1
+	}
+
+	return strconv.Itoa(risk), nil
+}
+
+// Part2 multiplies together the sizes of the three largest basins
+func Part2(p *Puzzle) (string, error) {
+	return part2(p, nil)
+}
+
+func part2(p *Puzzle, t *trace.Tracer) (string, error) {
+	basinSizes := make([]int, 0)
+
+	for _, low := range p.lowPoints() {
+		// go outward from basin
+		basin := p.Heights.FloodFill(low, grid.Orthogonal, func(from, to grid.Point) bool {
+			return p.Heights.Get(to) > p.Heights.Get(from) && p.Heights.Get(to) != 9
+		})
+
+		basinSizes = append(basinSizes, len(basin))
+		t.Debugf("Basin at %d,%d covers %d points", low.X, low.Y, len(basin))
+	}
+
+	if len(basinSizes) < 3 {
+		return "", fmt.Errorf("Only %d basins, want at least 3", len(basinSizes))
+	}
+
+	sort.Ints(basinSizes)
+	ret := 1
+	for _, v := range basinSizes[len(basinSizes)-3:] {
+		ret *= v
+	}
+	return strconv.Itoa(ret), nil
+}
+
+// lowPoints returns the points lower than all of their neighbors
+func (p *Puzzle) lowPoints() []grid.Point {
+	points := make([]grid.Point, 0)
+
+	for _, pos := range p.Heights.Points() {
+		isLow := true
+		for _, n := range p.Heights.Neighbors(pos, grid.Orthogonal) {
+			if p.Heights.Get(pos) >= p.Heights.Get(n) {
+				isLow = false
+			}
+		}
+		if isLow {
+			points = append(points, pos)
+		}
+	}
+
+	return points
+}
+
+// Parse reads one row of single digit heights per line. Every row must be the same length.
+func Parse(input io.Reader) (*Puzzle, error) {
+	heights, err := grid.Read(parse.NewScanner(input), grid.Digits, "a row of heights")
+	if err != nil {
+		return nil, err
+	}
+	return &Puzzle{Heights: heights}, nil
+}
@@ -1,24 +1,92 @@
 package day10
 
 import (
-	"bufio"
+	"fmt"
 	"github.com/ryderlewis/aoc2021/pkg/challenge"
+	"github.com/ryderlewis/aoc2021/pkg/parse"
 	"io"
 	"sort"
 	"strconv"
 )
 
+// Puzzle is the navigation subsystem, one line of chunks per entry
+type Puzzle struct {
+	Lines []string
+}
+
 type Runner struct {
-	lines []string
+	puzzle *Puzzle
 }
 
 var _ challenge.DailyChallenge = &Runner{}
+var _ challenge.PhasedChallenge = &Runner{}
+var _ challenge.Explainer = &Runner{}
+
+func init() {
+	challenge.Register(2021, 10, func() challenge.DailyChallenge {
+		return &Runner{}
+	})
+}
 
 func (r *Runner) Challenge1(input io.Reader) (string, error) {
-	if err := r.readInput(input); err != nil {
+	if err := r.Load(input); err != nil {
 		return "", err
 	}
 
+	return r.Solve1()
+}
+
+func (r *Runner) Challenge2(input io.Reader) (string, error) {
+	if err := r.Load(input); err != nil {
+		return "", err
+	}
+
+	return r.Solve2()
+}
+
+func (r *Runner) Challenge1Explain(input io.Reader, e *challenge.Explanation) (string, error) {
+	if err := r.Load(input); err != nil {
+		return "", err
+	}
+
+	return part1(r.puzzle, e)
+}
+
+func (r *Runner) Challenge2Explain(input io.Reader, e *challenge.Explanation) (string, error) {
+	if err := r.Load(input); err != nil {
+		return "", err
+	}
+
+	return part2(r.puzzle, e)
+}
+
+func (r *Runner) Load(input io.Reader) (err error) {
+	r.puzzle, err = Parse(input)
+	return err
+}
+
+func (r *Runner) Solve1() (string, error) {
+	return Part1(r.puzzle)
+}
+
+func (r *Runner) Solve2() (string, error) {
+	return Part2(r.puzzle)
+}
+
+// closers are the characters closing each kind of chunk
+var closers = map[rune]rune{
+	'(': ')',
+	'[': ']',
+	'{': '}',
+	'<': '>',
+}
+
+// Part1 sums the syntax error scores of the corrupted lines
+func Part1(p *Puzzle) (string, error) {
+	return part1(p, nil)
+}
+
+func part1(p *Puzzle, e *challenge.Explanation) (string, error) {
 	scores := map[rune]int{
 		')': 3,
 		']': 57,
@@ -27,7 +95,7 @@ func (r *Runner) Challenge1(input io.Reader) (string, error) {
 	}
 
 	score := 0
-	for _, line := range r.lines {
+	for n, line := range p.Lines {
 		stack := make([]rune, 0)
 		for i := 0; i < len(line); i++ {
 			r := rune(line[i])
@@ -70,6 +138,11 @@ func (r *Runner) Challenge1(input io.Reader) (string, error) {
 
 			if syntaxError {
 				score += scores[r]
+				if len(stack) == 0 {
+					e.Step("Line %d: %c at column %d closes nothing, scoring %d", n+1, r, i+1, scores[r])
+				} else {
+					e.Step("Line %d: %c at column %d should be %c, scoring %d", n+1, r, i+1, closers[stack[len(stack)-1]], scores[r])
+				}
 				break
 			}
 		}
@@ -78,11 +151,12 @@ func (r *Runner) Challenge1(input io.Reader) (string, error) {
 	return strconv.Itoa(score), nil
 }
 
-func (r *Runner) Challenge2(input io.Reader) (string, error) {
-	if err := r.readInput(input); err != nil {
-		return "", err
-	}
+// Part2 finds the middle completion score of the incomplete lines
+func Part2(p *Puzzle) (string, error) {
+	return part2(p, nil)
+}
 
+func part2(p *Puzzle, e *challenge.Explanation) (string, error) {
 	scores := map[rune]int{
 		'(': 1,
 		'[': 2,
@@ -91,7 +165,7 @@ func (r *Runner) Challenge2(input io.Reader) (string, error) {
 	}
 
 	scoreList := make([]int, 0)
-	for _, line := range r.lines {
+	for n, line := range p.Lines {
 		stack := make([]rune, 0)
 		syntaxError := false
 
@@ -140,28 +214,43 @@ func (r *Runner) Challenge2(input io.Reader) (string, error) {
 
 		if !syntaxError {
 			score := 0
+			completion := make([]rune, 0, len(stack))
 			for len(stack) > 0 {
 				lastRune := stack[len(stack)-1]
 				score *= 5
 				score += scores[lastRune]
 				stack = stack[:len(stack)-1]
+				completion = append(completion, closers[lastRune])
 			}
 			scoreList = append(scoreList, score)
+			e.Step("Line %d: completed by %s, scoring %d", n+1, string(completion), score)
 		}
 	}
 
+	if len(scoreList) == 0 {
+		return "", fmt.Errorf("No incomplete lines")
+	}
+
 	sort.Ints(scoreList)
 	score := scoreList[len(scoreList)/2]
+	e.Step("The middle of the %d scores is %d", len(scoreList), score)
 
 	return strconv.Itoa(score), nil
 }
 
-func (r *Runner) readInput(input io.Reader) error {
-	r.lines = make([]string, 0)
-	scanner := bufio.NewScanner(input)
+// Parse reads one line of chunks per line
+func Parse(input io.Reader) (*Puzzle, error) {
+	p := &Puzzle{Lines: make([]string, 0)}
+	scanner := parse.NewScanner(input)
 	for scanner.Scan() {
-		r.lines = append(r.lines, scanner.Text())
+		if err := scanner.Field().Only("([{<>}])"); err != nil {
+			return nil, err
+		}
+		p.Lines = append(p.Lines, scanner.Text())
 	}
 
-	return nil
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return p, nil
 }
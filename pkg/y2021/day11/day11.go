@@ -0,0 +1,189 @@
+package day11
+
+import (
+	"fmt"
+	"github.com/ryderlewis/aoc2021/pkg/challenge"
+	"github.com/ryderlewis/aoc2021/pkg/grid"
+	"github.com/ryderlewis/aoc2021/pkg/parse"
+	"github.com/ryderlewis/aoc2021/pkg/viz"
+	"io"
+	"strconv"
+)
+
+// Puzzle is the starting energy level of each octopus
+type Puzzle struct {
+	Energy *grid.Grid
+}
+
+type Runner struct {
+	puzzle *Puzzle
+}
+
+var _ challenge.DailyChallenge = &Runner{}
+var _ challenge.PhasedChallenge = &Runner{}
+var _ viz.Animator = &Runner{}
+
+func init() {
+	challenge.Register(2021, 11, func() challenge.DailyChallenge {
+		return &Runner{}
+	})
+}
+
+func (r *Runner) Challenge1(input io.Reader) (string, error) {
+	if err := r.Load(input); err != nil {
+		return "", err
+	}
+
+	return r.Solve1()
+}
+
+func (r *Runner) Challenge2(input io.Reader) (string, error) {
+	if err := r.Load(input); err != nil {
+		return "", err
+	}
+
+	return r.Solve2()
+}
+
+func (r *Runner) Challenge1Viz(input io.Reader, rec *viz.Recorder) (string, error) {
+	if err := r.Load(input); err != nil {
+		return "", err
+	}
+
+	return part1(r.puzzle, rec)
+}
+
+func (r *Runner) Challenge2Viz(input io.Reader, rec *viz.Recorder) (string, error) {
+	if err := r.Load(input); err != nil {
+		return "", err
+	}
+
+	return part2(r.puzzle, rec)
+}
+
+func (r *Runner) Load(input io.Reader) (err error) {
+	r.puzzle, err = Parse(input)
+	return err
+}
+
+func (r *Runner) Solve1() (string, error) {
+	return Part1(r.puzzle)
+}
+
+func (r *Runner) Solve2() (string, error) {
+	return Part2(r.puzzle)
+}
+
+// Part1 counts the flashes over 100 steps
+func Part1(p *Puzzle) (string, error) {
+	return part1(p, nil)
+}
+
+// Part2 finds the first step on which every octopus flashes. Some grids settle into a
+// cycle that never gets there, which is an error.
+func Part2(p *Puzzle) (string, error) {
+	return part2(p, nil)
+}
+
+func part1(p *Puzzle, rec *viz.Recorder) (string, error) {
+	energy := p.Energy.Copy()
+	record(rec, energy)
+
+	flashCount := 0
+	for steps := 0; steps < 100; steps++ {
+		flashCount += step(energy)
+		record(rec, energy)
+	}
+
+	return strconv.Itoa(flashCount), nil
+}
+
+func part2(p *Puzzle, rec *viz.Recorder) (string, error) {
+	energy := p.Energy.Copy()
+	seen := make(map[string]bool)
+	record(rec, energy)
+
+	steps := 0
+	for ; ; steps++ {
+		flashes := step(energy)
+		record(rec, energy)
+		if flashes == energy.Width*energy.Height {
+			break
+		}
+
+		state := energy.Render(grid.Digits)
+		if seen[state] {
+			return "", fmt.Errorf("Octopuses repeat after %d steps without all flashing", steps+1)
+		}
+		seen[state] = true
+	}
+
+	return strconv.Itoa(steps+1), nil
+}
+
+// flashed is the value recorded for an octopus that just flashed
+const flashed = 10
+
+// look shows the energy levels as digits, highlighting the octopuses that just flashed
+var look = viz.Style{
+	Max:       flashed,
+	Chars:     grid.Digits + "0",
+	Highlight: func(v int) bool { return v == flashed },
+}
+
+// record adds a frame of the energy levels, with the octopuses that just flashed
+// brightest of all
+func record(rec *viz.Recorder, energy *grid.Grid) {
+	if rec == nil {
+		return
+	}
+
+	frame := energy.Copy()
+	for _, pos := range frame.Points() {
+		if frame.Get(pos) == 0 {
+			frame.Set(pos, flashed)
+		}
+	}
+	rec.Frame(frame, look)
+}
+
+// step raises the energy of every octopus, lets them flash, and returns how many did
+func step(energy *grid.Grid) int {
+	flashes := make(map[grid.Point]bool)
+	points := energy.Points()
+
+	for _, pos := range points {
+		energy.Add(pos, 1)
+	}
+
+	for keepGoing := true; keepGoing; {
+		keepGoing = false
+		for _, pos := range points {
+			if energy.Get(pos) > 9 {
+				if _, ok := flashes[pos]; !ok {
+					flashes[pos] = true
+					keepGoing = true
+					for _, adjacent := range energy.Neighbors(pos, grid.Surrounding) {
+						energy.Add(adjacent, 1)
+					}
+				}
+			}
+		}
+	}
+
+	for pos := range flashes {
+		energy.Set(pos, 0)
+	}
+
+	return len(flashes)
+}
+
+// Parse reads one row of single digit energy levels per line. Every row must be the same
+// length.
+func Parse(input io.Reader) (*Puzzle, error) {
+	energy, err := grid.Read(parse.NewScanner(input), grid.Digits, "a row of energy levels")
+	if err != nil {
+		return nil, err
+	}
+	return &Puzzle{Energy: energy}, nil
+}
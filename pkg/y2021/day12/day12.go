@@ -0,0 +1,195 @@
+package day12
+
+import (
+	"fmt"
+	"github.com/ryderlewis/aoc2021/pkg/challenge"
+	"github.com/ryderlewis/aoc2021/pkg/parse"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Cave is a cave in the cave system. Small caves have lowercase names.
+type Cave struct {
+	Name      string
+	Small     bool
+	Neighbors []*Cave
+}
+
+// Puzzle is the cave system, keyed by cave name
+type Puzzle struct {
+	Caves map[string]*Cave
+}
+
+type Runner struct {
+	puzzle *Puzzle
+}
+
+var _ challenge.DailyChallenge = &Runner{}
+var _ challenge.PhasedChallenge = &Runner{}
+
+func init() {
+	challenge.Register(2021, 12, func() challenge.DailyChallenge {
+		return &Runner{}
+	})
+}
+
+func (r *Runner) Challenge1(input io.Reader) (string, error) {
+	if err := r.Load(input); err != nil {
+		return "", err
+	}
+
+	return r.Solve1()
+}
+
+func (r *Runner) Challenge2(input io.Reader) (string, error) {
+	if err := r.Load(input); err != nil {
+		return "", err
+	}
+
+	return r.Solve2()
+}
+
+func (r *Runner) Load(input io.Reader) (err error) {
+	r.puzzle, err = Parse(input)
+	return err
+}
+
+func (r *Runner) Solve1() (string, error) {
+	return Part1(r.puzzle)
+}
+
+func (r *Runner) Solve2() (string, error) {
+	return Part2(r.puzzle)
+}
+
+// Part1 counts the paths that visit small caves at most once
+func Part1(p *Puzzle) (string, error) {
+	// do a dfs through neighbors
+	visited := make(map[string]bool)
+	curr := p.Caves["start"]
+
+	count := countPaths(curr, visited)
+
+	return strconv.Itoa(count), nil
+}
+
+func countPaths(curr *Cave, visited map[string]bool) int {
+	count := 0
+
+	for _, neighbor := range curr.Neighbors {
+		if neighbor.Name == "end" {
+			count++
+			continue
+		}
+
+		if neighbor.Small {
+			if _, alreadyVisited := visited[neighbor.Name]; alreadyVisited {
+				continue
+			}
+		}
+
+		visited[curr.Name] = true
+		count += countPaths(neighbor, visited)
+		delete(visited, curr.Name)
+	}
+
+	return count
+}
+
+// Part2 counts the paths that visit a single small cave twice, and the rest at most once
+func Part2(p *Puzzle) (string, error) {
+	// do a dfs through neighbors
+	visited := make(map[string]bool)
+	curr := p.Caves["start"]
+
+	count := countPaths2(curr, nil, visited)
+
+	return strconv.Itoa(count), nil
+}
+
+func countPaths2(curr, double *Cave, visited map[string]bool) int {
+	count := 0
+
+	for _, neighbor := range curr.Neighbors {
+		if neighbor.Name == "end" {
+			if double == nil {
+				count++
+			} else {
+				// if double is true, make sure the double was visited a 2nd time, otherwise this is a dup
+				if _, doubleVisited := visited[double.Name]; doubleVisited || curr == double {
+					count++
+				}
+			}
+			continue
+		}
+
+		if neighbor.Small {
+			if _, alreadyVisited := visited[neighbor.Name]; alreadyVisited {
+				continue
+			}
+		}
+
+		// try once visiting the current cave
+		visited[curr.Name] = true
+		count += countPaths2(neighbor, double, visited)
+		delete(visited, curr.Name)
+
+		// try again if the current cave is small, and there is not a double, making it the double
+		if curr.Small && curr.Name != "start" && curr.Name != "end" && double == nil {
+			count += countPaths2(neighbor, curr, visited)
+		}
+	}
+
+	return count
+}
+
+// isBig reports whether a cave is big. Small caves are named in lower case.
+func isBig(name string) bool {
+	return strings.ToLower(name) != name
+}
+
+var connectionSyntax = regexp.MustCompile(`^([A-Za-z]+)-([A-Za-z]+)$`)
+
+// Parse reads one connection between two caves per line, such as "start-A". There must
+// be a start and an end cave.
+func Parse(input io.Reader) (*Puzzle, error) {
+	scanner := parse.NewScanner(input)
+	p := &Puzzle{Caves: make(map[string]*Cave)}
+
+	for scanner.Scan() {
+		fields, err := scanner.Field().Match(connectionSyntax, `two caves such as "start-A"`)
+		if err != nil {
+			return nil, err
+		}
+		s := []string{fields[0].Text, fields[1].Text}
+		if isBig(s[0]) && isBig(s[1]) {
+			// there would be no end to the paths bouncing between them
+			return nil, fields[1].Errorf("%w: two big caves can't be connected", parse.ErrSyntax)
+		}
+
+		for _, n := range s {
+			if _, exists := p.Caves[n]; !exists {
+				p.Caves[n] = &Cave{
+					Name:      n,
+					Small:     !isBig(n),
+					Neighbors: make([]*Cave, 0),
+				}
+			}
+		}
+
+		p.Caves[s[0]].Neighbors = append(p.Caves[s[0]].Neighbors, p.Caves[s[1]])
+		p.Caves[s[1]].Neighbors = append(p.Caves[s[1]].Neighbors, p.Caves[s[0]])
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	for _, name := range []string{"start", "end"} {
+		if _, ok := p.Caves[name]; !ok {
+			return nil, scanner.Truncated(fmt.Sprintf("a connection to the %s cave", name))
+		}
+	}
+	return p, nil
+}
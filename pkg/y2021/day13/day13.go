@@ -0,0 +1,390 @@
+package day13
+
+import (
+	"context"
+	"fmt"
+	"github.com/ryderlewis/aoc2021/pkg/challenge"
+	"github.com/ryderlewis/aoc2021/pkg/grid"
+	"github.com/ryderlewis/aoc2021/pkg/parse"
+	"github.com/ryderlewis/aoc2021/pkg/viz"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type Dot struct {
+	X, Y int
+}
+
+type Fold struct {
+	AlongX bool
+	AlongY bool
+	Value  int
+}
+
+// Puzzle is the transparent paper's dots and the folding instructions
+type Puzzle struct {
+	Dots          map[Dot]bool
+	Width, Height int
+	Folds         []Fold
+}
+
+// sheet is a copy of the paper that is folded while solving
+type sheet struct {
+	dots          map[Dot]bool
+	width, height int
+}
+
+type Runner struct {
+	puzzle *Puzzle
+}
+
+var _ challenge.DailyChallenge = &Runner{}
+var _ challenge.PhasedChallenge = &Runner{}
+var _ challenge.ResultChallenge = &Runner{}
+var _ viz.Animator = &Runner{}
+
+func init() {
+	challenge.Register(2021, 13, func() challenge.DailyChallenge {
+		return &Runner{}
+	})
+}
+
+func (r *Runner) Challenge1(input io.Reader) (string, error) {
+	if err := r.Load(input); err != nil {
+		return "", err
+	}
+
+	return r.Solve1()
+}
+
+func (r *Runner) Challenge2(input io.Reader) (string, error) {
+	if err := r.Load(input); err != nil {
+		return "", err
+	}
+
+	return r.Solve2()
+}
+
+func (r *Runner) Challenge1Result(ctx context.Context, input io.Reader) (*challenge.Result, error) {
+	answer, err := r.Challenge1(input)
+	if err != nil {
+		return nil, err
+	}
+
+	return &challenge.Result{Answer: answer}, nil
+}
+
+func (r *Runner) Challenge2Result(ctx context.Context, input io.Reader) (*challenge.Result, error) {
+	if err := r.Load(input); err != nil {
+		return nil, err
+	}
+
+	return part2(r.puzzle, nil)
+}
+
+func (r *Runner) Challenge1Viz(input io.Reader, rec *viz.Recorder) (string, error) {
+	if err := r.Load(input); err != nil {
+		return "", err
+	}
+
+	return part1(r.puzzle, rec)
+}
+
+func (r *Runner) Challenge2Viz(input io.Reader, rec *viz.Recorder) (string, error) {
+	if err := r.Load(input); err != nil {
+		return "", err
+	}
+
+	result, err := part2(r.puzzle, rec)
+	if err != nil {
+		return "", err
+	}
+
+	return result.Answer, nil
+}
+
+func (r *Runner) Load(input io.Reader) (err error) {
+	r.puzzle, err = Parse(input)
+	return err
+}
+
+func (r *Runner) Solve1() (string, error) {
+	return Part1(r.puzzle)
+}
+
+func (r *Runner) Solve2() (string, error) {
+	return Part2(r.puzzle)
+}
+
+// Part1 counts the dots visible after the first fold
+func Part1(p *Puzzle) (string, error) {
+	return part1(p, nil)
+}
+
+func part1(p *Puzzle, rec *viz.Recorder) (string, error) {
+	s := p.sheet()
+	s.record(rec)
+	s.fold(p.Folds[0])
+	s.record(rec)
+
+	return strconv.Itoa(len(s.dots)), nil
+}
+
+// Part2 reads the code spelled out by the dots once the paper is completely folded
+func Part2(p *Puzzle) (string, error) {
+	result, err := part2(p, nil)
+	if err != nil {
+		return "", err
+	}
+
+	return result.Answer, nil
+}
+
+// part2 folds the paper completely. The answer is the code spelled out by the dots, and
+// the folded paper itself is attached as a grid. The paper is recorded after every fold.
+func part2(p *Puzzle, rec *viz.Recorder) (*challenge.Result, error) {
+	s := p.sheet()
+	s.record(rec)
+	for _, f := range p.Folds {
+		s.fold(f)
+		s.record(rec)
+	}
+	if s.width <= 0 || s.height <= 0 || s.width > maxPaper || s.height > maxPaper || s.width*s.height > maxPaper {
+		return nil, fmt.Errorf("Paper is %dx%d after folding, which can't be read", s.width, s.height)
+	}
+
+	result := &challenge.Result{Answer: s.ocr()}
+	result.AddGrid("paper", s.render())
+
+	return result, nil
+}
+
+// maxPaper is the most area the paper can cover once folded and still be read. The code
+// itself takes up 40 by 6.
+const maxPaper = 1 << 16
+
+// sheet returns a copy of the paper that can be folded without changing the puzzle
+func (p *Puzzle) sheet() *sheet {
+	s := &sheet{
+		dots:   make(map[Dot]bool, len(p.Dots)),
+		width:  p.Width,
+		height: p.Height,
+	}
+	for dot := range p.Dots {
+		s.dots[dot] = true
+	}
+
+	return s
+}
+
+func (s *sheet) fold(fold Fold) {
+	delPoints := make([]Dot, 0)
+	addPoints := make([]Dot, 0)
+
+	if fold.AlongX {
+		if fold.Value < s.width {
+			s.width = fold.Value
+		}
+		for pos, _ := range s.dots {
+			if pos.X > fold.Value {
+				delPoints = append(delPoints, pos)
+				addPoints = append(addPoints, Dot{
+					X: 2*fold.Value - pos.X,
+					Y: pos.Y,
+				})
+			}
+		}
+	} else {
+		if fold.Value < s.height {
+			s.height = fold.Value
+		}
+
+		for pos, _ := range s.dots {
+			if pos.Y > fold.Value {
+				delPoints = append(delPoints, pos)
+				addPoints = append(addPoints, Dot{
+					X: pos.X,
+					Y: 2*fold.Value - pos.Y,
+				})
+			}
+		}
+	}
+
+	for _, pos := range delPoints {
+		delete(s.dots, pos)
+	}
+
+	for _, pos := range addPoints {
+		s.dots[pos] = true
+	}
+}
+
+// maxFrame is the most area of paper that is recorded. Larger sheets are skipped until
+// they are folded down to size.
+const maxFrame = 1 << 22
+
+// look shows the dots as #
+var look = viz.Style{Max: 1, Chars: ".#"}
+
+// record adds a frame of the paper, with the dots lit
+func (s *sheet) record(rec *viz.Recorder) {
+	if rec == nil || s.width <= 0 || s.height <= 0 || s.width > maxFrame || s.height > maxFrame || s.width*s.height > maxFrame {
+		return
+	}
+
+	frame := grid.New(s.width, s.height)
+	for dot := range s.dots {
+		if pos := (grid.Point{X: dot.X, Y: dot.Y}); frame.In(pos) {
+			frame.Set(pos, 1)
+		}
+	}
+	rec.Frame(frame, look)
+}
+
+func (s *sheet) render() []string {
+	rows := make([]string, s.height)
+	buf := make([]rune, s.width)
+
+	for y := 0; y < s.height; y++ {
+		for x := 0; x < s.width; x++ {
+			pos := Dot{X: x, Y: y}
+			if _, contains := s.dots[pos]; contains {
+				buf[x] = '#'
+			} else {
+				buf[x] = ' '
+			}
+		}
+		rows[y] = string(buf)
+	}
+
+	return rows
+}
+
+// letters maps each glyph of the puzzle font, read row by row, to its letter
+var letters = map[string]rune{
+	".##.#..##..######..##..#": 'A',
+	"###.#..####.#..##..####.": 'B',
+	".##.#..##...#...#..#.##.": 'C',
+	"#####...###.#...#...####": 'E',
+	"#####...###.#...#...#...": 'F',
+	".##.#..##...#.###..#.###": 'G',
+	"#..##..######..##..##..#": 'H',
+	".###..#...#...#...#..###": 'I',
+	"..##...#...#...##..#.##.": 'J',
+	"#..##.#.##..#.#.#.#.#..#": 'K',
+	"#...#...#...#...#...####": 'L',
+	".##.#..##..##..##..#.##.": 'O',
+	"###.#..##..####.#...#...": 'P',
+	"###.#..##..####.#.#.#..#": 'R',
+	".####...#....##....####.": 'S',
+	"#..##..##..##..##..#.##.": 'U',
+	"####...#..#..#..#...####": 'Z',
+}
+
+// ocr reads the letters spelled out by the dots on the folded paper. Each letter is four
+// dots wide and six tall, followed by a blank column. Unrecognized glyphs read as '?'.
+func (s *sheet) ocr() string {
+	var code strings.Builder
+
+	for left := 0; left < s.width; left += 5 {
+		var glyph strings.Builder
+		for y := 0; y < 6; y++ {
+			for x := left; x < left+4; x++ {
+				if s.dots[Dot{X: x, Y: y}] {
+					glyph.WriteRune('#')
+				} else {
+					glyph.WriteRune('.')
+				}
+			}
+		}
+
+		if letter, ok := letters[glyph.String()]; ok {
+			code.WriteRune(letter)
+		} else {
+			code.WriteRune('?')
+		}
+	}
+
+	return code.String()
+}
+
+var foldSyntax = regexp.MustCompile(`^fold along ([xy])=(\d+)$`)
+
+// Parse reads the dot coordinates, one "x,y" per line, followed by a blank line and then
+// at least one "fold along" instruction
+func Parse(input io.Reader) (*Puzzle, error) {
+	scanner := parse.NewScanner(input)
+	p := &Puzzle{
+		Dots:  make(map[Dot]bool),
+		Folds: make([]Fold, 0),
+	}
+
+	for {
+		line, err := scanner.Next()
+		if err != nil {
+			return nil, err
+		}
+		if line.Text == "" {
+			if len(p.Dots) == 0 {
+				return nil, line.Errorf("%w: want at least one dot", parse.ErrSyntax)
+			}
+			break
+		}
+
+		coords, err := line.SplitN(",", 2)
+		if err != nil {
+			return nil, err
+		}
+		x, err := coords[0].Int()
+		if err != nil {
+			return nil, err
+		}
+		y, err := coords[1].Int()
+		if err != nil {
+			return nil, err
+		}
+		if x < 0 || y < 0 {
+			return nil, line.Errorf("%w: want a dot that isn't negative", parse.ErrInteger)
+		}
+
+		dot := Dot{
+			X: x,
+			Y: y,
+		}
+		if x+1 > p.Width {
+			p.Width = x + 1
+		}
+		if y+1 > p.Height {
+			p.Height = y + 1
+		}
+		p.Dots[dot] = true
+	}
+
+	for scanner.Scan() {
+		fields, err := scanner.Field().Match(foldSyntax, `"fold along x=N" or "fold along y=N"`)
+		if err != nil {
+			return nil, err
+		}
+		value, err := fields[1].Int()
+		if err != nil {
+			return nil, err
+		}
+
+		fold := Fold{
+			Value:  value,
+			AlongX: fields[0].Text == "x",
+			AlongY: fields[0].Text == "y",
+		}
+		p.Folds = append(p.Folds, fold)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(p.Folds) == 0 {
+		return nil, scanner.Truncated("a fold instruction")
+	}
+	return p, nil
+}
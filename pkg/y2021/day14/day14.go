@@ -0,0 +1,257 @@
+package day14
+
+import (
+	"fmt"
+	"github.com/ryderlewis/aoc2021/pkg/challenge"
+	"github.com/ryderlewis/aoc2021/pkg/parse"
+	"github.com/ryderlewis/aoc2021/pkg/trace"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type Node struct {
+	val  rune
+	next *Node
+}
+
+// Puzzle is the polymer template and the pair insertion rules
+type Puzzle struct {
+	Template string
+	Rules    map[string]rune
+}
+
+// polymer is the state of a polymer as pair insertion is run on it. Part 1 works on the
+// linked list of elements, and part 2 on the counts of each pair.
+type polymer struct {
+	head  *Node
+	rules map[string]rune
+	pairs map[string]int
+	first rune
+}
+
+type Runner struct {
+	puzzle *Puzzle
+	trace  *trace.Tracer
+}
+
+var _ challenge.DailyChallenge = &Runner{}
+var _ challenge.PhasedChallenge = &Runner{}
+var _ trace.Traced = &Runner{}
+
+func init() {
+	challenge.Register(2021, 14, func() challenge.DailyChallenge {
+		return &Runner{}
+	})
+}
+
+func (r *Runner) Challenge1(input io.Reader) (string, error) {
+	if err := r.Load(input); err != nil {
+		return "", err
+	}
+
+	return r.Solve1()
+}
+
+func (r *Runner) Challenge2(input io.Reader) (string, error) {
+	if err := r.Load(input); err != nil {
+		return "", err
+	}
+
+	return r.Solve2()
+}
+
+func (r *Runner) Load(input io.Reader) (err error) {
+	r.puzzle, err = Parse(input)
+	return err
+}
+
+func (r *Runner) SetTracer(t *trace.Tracer) {
+	r.trace = t
+}
+
+func (r *Runner) Solve1() (string, error) {
+	return Part1(r.puzzle)
+}
+
+func (r *Runner) Solve2() (string, error) {
+	return strconv.Itoa(polymerize(r.puzzle, 40, r.trace)), nil
+}
+
+// Part1 runs ten steps of pair insertion on a linked list of elements
+func Part1(p *Puzzle) (string, error) {
+	poly := p.polymer()
+	counts := make(map[rune]int)
+	for i := 0; i < 10; i++ {
+		poly.polymerize()
+	}
+
+	for curr := poly.head; curr != nil; curr = curr.next {
+		counts[curr.val]++
+	}
+
+	vals := make([]int, 0)
+	for _, count := range counts {
+		vals = append(vals, count)
+	}
+	sort.Ints(vals)
+
+	return strconv.Itoa(vals[len(vals)-1] - vals[0]), nil
+}
+
+// Part2 runs forty steps of pair insertion, tracking only counts of each pair
+func Part2(p *Puzzle) (string, error) {
+	return strconv.Itoa(Polymerize(p, 40)), nil
+}
+
+// Polymerize runs steps of pair insertion, tracking only counts of each pair, and returns
+// the difference between the most and least common elements
+func Polymerize(p *Puzzle, steps int) int {
+	return polymerize(p, steps, nil)
+}
+
+func polymerize(p *Puzzle, steps int, t *trace.Tracer) int {
+	poly := p.polymer()
+	counts := make(map[rune]int)
+	counts[poly.first] = 1
+	for i := 0; i < steps; i++ {
+		poly.polymerize2()
+	}
+
+	for pair, c := range poly.pairs {
+		counts[rune(pair[1])] += c
+	}
+
+	vals := make([]int, 0)
+	for _, count := range counts {
+		if count > 0 {
+			vals = append(vals, count)
+		}
+	}
+	sort.Ints(vals)
+
+	if t.Enabled(trace.Info) {
+		elements := make([]string, 0, len(counts))
+		for val, count := range counts {
+			elements = append(elements, fmt.Sprintf("%c: %d", val, count))
+		}
+		sort.Strings(elements)
+		t.Infof("Element counts after %d steps: %s", steps, strings.Join(elements, ", "))
+	}
+
+	return vals[len(vals)-1] - vals[0]
+}
+
+// polymer builds the starting polymer from the template
+func (p *Puzzle) polymer() *polymer {
+	poly := &polymer{
+		rules: p.Rules,
+		pairs: make(map[string]int),
+	}
+
+	var curr, last *Node
+	for i := 0; i < len(p.Template); i++ {
+		curr = &Node{val: rune(p.Template[i])}
+		if i == 0 {
+			poly.head = curr
+		} else {
+			last.next = curr
+		}
+		last = curr
+	}
+
+	for i := 0; i < len(p.Template)-1; i++ {
+		pair := p.Template[i : i+2]
+		poly.pairs[pair]++
+	}
+	poly.first = rune(p.Template[0])
+
+	return poly
+}
+
+func (poly *polymer) polymerize() {
+	var curr, next *Node
+	var buf strings.Builder
+	buf.Grow(2)
+
+	curr = poly.head
+	next = curr.next
+
+	for next != nil {
+		buf.Reset()
+		buf.WriteRune(curr.val)
+		buf.WriteRune(next.val)
+
+		if insert, ok := poly.rules[buf.String()]; ok {
+			mid := &Node{
+				val:  insert,
+				next: next,
+			}
+			curr.next = mid
+		}
+
+		curr = next
+		next = curr.next
+	}
+}
+
+func (poly *polymer) polymerize2() {
+	add := make(map[string]int)
+	sub := make(map[string]int)
+
+	for p, c := range poly.pairs {
+		if insert, ok := poly.rules[p]; ok {
+			sub[p] += c
+			add[fmt.Sprintf("%c%c", p[0], insert)] += c
+			add[fmt.Sprintf("%c%c", insert, p[1])] += c
+		}
+	}
+
+	for p, c := range add {
+		poly.pairs[p] += c
+	}
+
+	for p, c := range sub {
+		poly.pairs[p] -= c
+	}
+}
+
+var ruleSyntax = regexp.MustCompile(`^([A-Z]{2}) -> ([A-Z])$`)
+
+// Parse reads the template from the first line, then a blank line, followed by rules
+// such as "CH -> B"
+func Parse(input io.Reader) (*Puzzle, error) {
+	p := &Puzzle{Rules: make(map[string]rune)}
+
+	scanner := parse.NewScanner(input)
+	template, err := scanner.Next()
+	if err != nil {
+		return nil, err
+	}
+	if err := template.Only("ABCDEFGHIJKLMNOPQRSTUVWXYZ"); err != nil {
+		return nil, err
+	}
+	if template.Text == "" {
+		return nil, template.Errorf("%w: want a polymer template", parse.ErrSyntax)
+	}
+	p.Template = template.Text
+
+	if err := scanner.Blank(); err != nil {
+		return nil, err
+	}
+
+	for scanner.Scan() {
+		fields, err := scanner.Field().Match(ruleSyntax, `a rule such as "CH -> B"`)
+		if err != nil {
+			return nil, err
+		}
+		p.Rules[fields[0].Text] = rune(fields[1].Text[0])
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
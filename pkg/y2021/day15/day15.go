@@ -0,0 +1,251 @@
+package day15
+
+import (
+	"context"
+	"fmt"
+	"github.com/ryderlewis/aoc2021/pkg/challenge"
+	"github.com/ryderlewis/aoc2021/pkg/grid"
+	"github.com/ryderlewis/aoc2021/pkg/parse"
+	"github.com/ryderlewis/aoc2021/pkg/search"
+	"github.com/ryderlewis/aoc2021/pkg/viz"
+	"io"
+	"strconv"
+)
+
+// Puzzle is the risk level of each position in the cave
+type Puzzle struct {
+	Risk *grid.Grid
+}
+
+type Runner struct {
+	puzzle *Puzzle
+}
+
+// cavern is the cave as a graph to search, from the top left to the bottom right, where
+// entering a position costs its risk
+type cavern struct {
+	risk *grid.Grid
+	end  grid.Point
+}
+
+var _ search.Graph = &cavern{}
+
+var _ challenge.DailyChallenge = &Runner{}
+var _ challenge.PhasedChallenge = &Runner{}
+var _ challenge.ContextChallenge = &Runner{}
+var _ challenge.ResultChallenge = &Runner{}
+var _ viz.Animator = &Runner{}
+
+func init() {
+	challenge.Register(2021, 15, func() challenge.DailyChallenge {
+		return &Runner{}
+	})
+}
+
+func (r *Runner) Challenge1(input io.Reader) (string, error) {
+	return r.Challenge1Context(context.Background(), input)
+}
+
+func (r *Runner) Challenge1Context(ctx context.Context, input io.Reader) (string, error) {
+	return answer(r.Challenge1Result(ctx, input))
+}
+
+func (r *Runner) Challenge1Result(ctx context.Context, input io.Reader) (*challenge.Result, error) {
+	if err := r.Load(input); err != nil {
+		return nil, err
+	}
+
+	return solve(ctx, r.puzzle, 1, nil)
+}
+
+func (r *Runner) Challenge2(input io.Reader) (string, error) {
+	return r.Challenge2Context(context.Background(), input)
+}
+
+func (r *Runner) Challenge2Context(ctx context.Context, input io.Reader) (string, error) {
+	return answer(r.Challenge2Result(ctx, input))
+}
+
+func (r *Runner) Challenge2Result(ctx context.Context, input io.Reader) (*challenge.Result, error) {
+	if err := r.Load(input); err != nil {
+		return nil, err
+	}
+
+	return solve(ctx, r.puzzle, 5, nil)
+}
+
+func (r *Runner) Challenge1Viz(input io.Reader, rec *viz.Recorder) (string, error) {
+	if err := r.Load(input); err != nil {
+		return "", err
+	}
+
+	return answer(solve(context.Background(), r.puzzle, 1, rec))
+}
+
+func (r *Runner) Challenge2Viz(input io.Reader, rec *viz.Recorder) (string, error) {
+	if err := r.Load(input); err != nil {
+		return "", err
+	}
+
+	return answer(solve(context.Background(), r.puzzle, 5, rec))
+}
+
+func (r *Runner) Load(input io.Reader) (err error) {
+	r.puzzle, err = Parse(input)
+	return err
+}
+
+func (r *Runner) Solve1() (string, error) {
+	return Part1(r.puzzle)
+}
+
+func (r *Runner) Solve2() (string, error) {
+	return Part2(r.puzzle)
+}
+
+// Part1 finds the lowest total risk of any path through the cave
+func Part1(p *Puzzle) (string, error) {
+	return answer(solve(context.Background(), p, 1, nil))
+}
+
+// Part2 finds the lowest total risk of any path through the cave grown five times in
+// each direction
+func Part2(p *Puzzle) (string, error) {
+	return answer(solve(context.Background(), p, 5, nil))
+}
+
+func answer(result *challenge.Result, err error) (string, error) {
+	if err != nil {
+		return "", err
+	}
+	return result.Answer, nil
+}
+
+// solve finds the least risky path through the map after growing it n times in each
+// direction. The path itself is attached to the result, from top left to bottom right,
+// and recorded being drawn over the map.
+func solve(ctx context.Context, p *Puzzle, n int, rec *viz.Recorder) (*challenge.Result, error) {
+	risk := embiggify(p.Risk, n)
+	r := &cavern{
+		risk: risk,
+		end:  grid.Point{X: risk.Width - 1, Y: risk.Height - 1},
+	}
+
+	// every step costs at least 1, so the manhattan distance never overestimates
+	found, err := search.AStar(ctx, r, grid.Point{}, r.distance)
+	if ctx.Err() != nil {
+		return nil, challenge.Aborted(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	r.record(rec, found.Path)
+
+	steps := make([]string, len(found.Path))
+	for i, s := range found.Path {
+		c := s.(grid.Point)
+		steps[i] = fmt.Sprintf("%d,%d", c.X, c.Y)
+	}
+
+	result := &challenge.Result{Answer: strconv.Itoa(found.Cost)}
+	result.AddStat("steps", len(found.Path)-1)
+	result.AddStat("expanded", found.Expanded)
+	result.AddPath("path", steps)
+
+	return result, nil
+}
+
+// embiggify grows the map n times in each direction, with risk increasing in each copy
+func embiggify(risk *grid.Grid, n int) *grid.Grid {
+	cols := risk.Width
+	rows := risk.Height
+
+	newGrid := grid.New(cols*n, rows*n)
+
+	for dx := 0; dx < n; dx++ {
+		for dy := 0; dy < n; dy++ {
+			delta := dx + dy
+			offset := grid.Point{X: cols * dx, Y: rows * dy}
+
+			for _, pos := range risk.Points() {
+				origVal := risk.Get(pos)
+				newVal := origVal + delta
+				if newVal > 9 {
+					newVal %= 9
+				}
+
+				newGrid.Set(pos.Add(offset), newVal)
+			}
+		}
+	}
+
+	return newGrid
+}
+
+// pathFrames is the most frames used to draw the path
+const pathFrames = 100
+
+// pathRisk is the value the path is drawn with, twice the highest risk so the map
+// beneath it is dimmed
+const pathRisk = 18
+
+// look shows the risk of each position as a digit, and the path as stars
+var look = viz.Style{Max: pathRisk, Chars: grid.Digits + "*"}
+
+// record adds frames of the path being drawn over the map, from start to end, with the
+// risk of each position dimmed below it
+func (r *cavern) record(rec *viz.Recorder, path []search.State) {
+	if rec == nil {
+		return
+	}
+
+	frame := r.risk.Copy()
+	rec.Frame(frame, look)
+
+	every := (len(path) + pathFrames - 1) / pathFrames
+	for i, s := range path {
+		frame.Set(s.(grid.Point), pathRisk)
+		if (i+1)%every == 0 || i == len(path)-1 {
+			rec.Frame(frame, look)
+		}
+	}
+}
+
+// Neighbors returns the positions next to s, each costing its risk to enter
+func (r *cavern) Neighbors(s search.State) []search.Edge {
+	neighbors := r.risk.Neighbors(s.(grid.Point), grid.Orthogonal)
+	edges := make([]search.Edge, len(neighbors))
+	for i, n := range neighbors {
+		edges[i] = search.Edge{To: n, Cost: r.risk.Get(n)}
+	}
+
+	return edges
+}
+
+// Goal reports whether s is the bottom right of the cave
+func (r *cavern) Goal(s search.State) bool {
+	return s.(grid.Point) == r.end
+}
+
+// distance is the manhattan distance from s to the bottom right of the cave
+func (r *cavern) distance(s search.State) int {
+	c := s.(grid.Point)
+	return r.end.X - c.X + r.end.Y - c.Y
+}
+
+// riskLevels are the characters of the map. Risk is never 0, which the search relies on.
+const riskLevels = "123456789"
+
+// Parse reads one row of single digit risk levels, from 1 to 9, per line. Every row must
+// be the same length.
+func Parse(input io.Reader) (*Puzzle, error) {
+	risk, err := grid.Read(parse.NewScanner(input), riskLevels, "a row of risk levels")
+	if err != nil {
+		return nil, err
+	}
+	for _, pos := range risk.Points() {
+		risk.Add(pos, 1)
+	}
+	return &Puzzle{Risk: risk}, nil
+}
@@ -0,0 +1,339 @@
+package day16
+
+import (
+	"errors"
+	"fmt"
+	"github.com/ryderlewis/aoc2021/pkg/challenge"
+	"github.com/ryderlewis/aoc2021/pkg/parse"
+	"io"
+	"math"
+	"strconv"
+)
+
+const (
+	SUM         = 0
+	PRODUCT     = 1
+	MINIMUM     = 2
+	MAXIMUM     = 3
+	LITERAL     = 4
+	GREATERTHAN = 5
+	LESSTHAN    = 6
+	EQUAL       = 7
+)
+
+type Packet struct {
+	version int
+	typeId  int
+
+	// for typeId == 4
+	literal int // for typeId 4
+
+	// for typeId != 4
+	lengthTypeId       int // 0 == sub-packet bit length, 1 == number of sub-packets
+	subPacketBitLength int
+	subPacketCount     int
+
+	// raw data
+	bits       []int
+	subPackets []*Packet
+}
+
+// Puzzle is the decoded transmission
+type Puzzle struct {
+	Packet *Packet
+}
+
+type Runner struct {
+	puzzle *Puzzle
+}
+
+var _ challenge.DailyChallenge = &Runner{}
+var _ challenge.PhasedChallenge = &Runner{}
+
+func init() {
+	challenge.Register(2021, 16, func() challenge.DailyChallenge {
+		return &Runner{}
+	})
+}
+
+func (r *Runner) Challenge1(input io.Reader) (string, error) {
+	if err := r.Load(input); err != nil {
+		return "", err
+	}
+
+	return r.Solve1()
+}
+
+func (r *Runner) Challenge2(input io.Reader) (string, error) {
+	if err := r.Load(input); err != nil {
+		return "", err
+	}
+
+	return r.Solve2()
+}
+
+func (r *Runner) Load(input io.Reader) (err error) {
+	r.puzzle, err = Parse(input)
+	return err
+}
+
+func (r *Runner) Solve1() (string, error) {
+	return Part1(r.puzzle)
+}
+
+func (r *Runner) Solve2() (string, error) {
+	return Part2(r.puzzle)
+}
+
+// Part1 sums the version numbers of every packet
+func Part1(p *Puzzle) (string, error) {
+	val := sumVersions(p.Packet)
+
+	return strconv.Itoa(val), nil
+}
+
+// Part2 evaluates the expression encoded by the outermost packet
+func Part2(p *Puzzle) (string, error) {
+	val := doTheMath(p.Packet)
+
+	return strconv.Itoa(val), nil
+}
+
+func sumVersions(packet *Packet) int {
+	sum := packet.version
+
+	for _, sub := range packet.subPackets {
+		sum += sumVersions(sub)
+	}
+
+	return sum
+}
+
+func doTheMath(packet *Packet) int {
+	switch packet.typeId {
+	case SUM:
+		s := 0
+		for _, p := range packet.subPackets {
+			s += doTheMath(p)
+		}
+		return s
+	case PRODUCT:
+		v := 1
+		for _, p := range packet.subPackets {
+			v *= doTheMath(p)
+		}
+		return v
+	case MINIMUM:
+		v := math.MaxInt
+		for _, p := range packet.subPackets {
+			m := doTheMath(p)
+			if m < v {
+				v = m
+			}
+		}
+		return v
+	case MAXIMUM:
+		v := math.MinInt
+		for _, p := range packet.subPackets {
+			m := doTheMath(p)
+			if m > v {
+				v = m
+			}
+		}
+		return v
+	case GREATERTHAN:
+		v := 0
+		if doTheMath(packet.subPackets[0]) > doTheMath(packet.subPackets[1]) {
+			v = 1
+		}
+		return v
+	case LESSTHAN:
+		v := 0
+		if doTheMath(packet.subPackets[0]) < doTheMath(packet.subPackets[1]) {
+			v = 1
+		}
+		return v
+	case EQUAL:
+		v := 0
+		if doTheMath(packet.subPackets[0]) == doTheMath(packet.subPackets[1]) {
+			v = 1
+		}
+		return v
+	default:
+		return packet.literal
+	}
+}
+
+// Parse decodes the hexadecimal transmission on the first line into its outermost packet
+func Parse(input io.Reader) (*Puzzle, error) {
+	scanner := parse.NewScanner(input)
+	line, err := scanner.Next()
+	if err != nil {
+		return nil, err
+	}
+	if err := line.Only("0123456789ABCDEF"); err != nil {
+		return nil, err
+	}
+
+	bits := make([]int, len(line.Text)*4)
+	for nibble := 0; nibble < len(line.Text); nibble++ {
+		val := int(line.Text[nibble] - '0')
+		if line.Text[nibble] >= 'A' {
+			val = 10 + int(line.Text[nibble]-'A')
+		}
+		bits[nibble*4] = val >> 3 & 1
+		bits[nibble*4+1] = val >> 2 & 1
+		bits[nibble*4+2] = val >> 1 & 1
+		bits[nibble*4+3] = val & 1
+	}
+
+	p := &Puzzle{}
+	if p.Packet, _, err = packetFromBits(bits, 0); err != nil {
+		// point at the hex digit holding the bad bit
+		var perr *packetError
+		if errors.As(err, &perr) {
+			nibble := perr.pos / 4
+			if nibble >= len(line.Text) {
+				return nil, line.Slice(len(line.Text), len(line.Text)).Errorf("%w: %v", parse.ErrTruncated, err)
+			}
+			return nil, line.Slice(nibble, nibble+1).Errorf("%w: %v", parse.ErrSyntax, err)
+		}
+		return nil, err
+	}
+
+	if err := scanner.End(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// packetError describes a malformed packet, found at bit pos of the transmission. Packets
+// that run past the end of the bits available to them are reported at the end.
+type packetError struct {
+	pos int
+	msg string
+}
+
+func (e *packetError) Error() string {
+	return fmt.Sprintf("bit %d: %s", e.pos, e.msg)
+}
+
+// packetFromBits decodes the packet that starts at bit pos, returning it along with the
+// number of bits it used. Sub-packets must fit within bits.
+func packetFromBits(bits []int, pos int) (*Packet, int, error) {
+	if pos+6 > len(bits) {
+		return nil, 0, &packetError{len(bits), "packet header runs past the end"}
+	}
+
+	packet := &Packet{
+		bits:       bits[pos:],
+		version:    bits[pos]<<2 | bits[pos+1]<<1 | bits[pos+2],
+		typeId:     bits[pos+3]<<2 | bits[pos+4]<<1 | bits[pos+5],
+		subPackets: make([]*Packet, 0),
+	}
+	consumed := 6
+
+	if packet.typeId == 4 {
+		var c int
+		var err error
+		packet.literal, c, err = intFromBits(bits, pos+6, 0, true)
+		if err != nil {
+			return nil, 0, err
+		}
+		consumed += c
+	} else {
+		if pos+7 > len(bits) {
+			return nil, 0, &packetError{len(bits), "operator packet runs past the end"}
+		}
+		packet.lengthTypeId = bits[pos+6]
+		consumed++
+
+		if packet.lengthTypeId == 0 {
+			// next 15 bits are total length in bits of sub-packets
+			var err error
+			if packet.subPacketBitLength, _, err = intFromBits(bits, pos+7, 15, false); err != nil {
+				return nil, 0, err
+			}
+			consumed += 15
+
+			start := pos + consumed
+			end := start + packet.subPacketBitLength
+			if end > len(bits) {
+				return nil, 0, &packetError{len(bits), "sub-packets run past the end"}
+			}
+			consumed += packet.subPacketBitLength
+
+			for start < end {
+				subPacket, bitCount, err := packetFromBits(bits[:end], start)
+				if err != nil {
+					return nil, 0, err
+				}
+				packet.subPackets = append(packet.subPackets, subPacket)
+				start += bitCount
+			}
+		} else {
+			// next 11 bits are sub-packet count
+			var err error
+			if packet.subPacketCount, _, err = intFromBits(bits, pos+7, 11, false); err != nil {
+				return nil, 0, err
+			}
+			consumed += 11
+
+			for i := 0; i < packet.subPacketCount; i++ {
+				subPacket, bitCount, err := packetFromBits(bits, pos+consumed)
+				if err != nil {
+					return nil, 0, err
+				}
+				consumed += bitCount
+				packet.subPackets = append(packet.subPackets, subPacket)
+			}
+		}
+
+		switch {
+		case len(packet.subPackets) == 0:
+			return nil, 0, &packetError{pos, "operator packet has no sub-packets"}
+		case packet.typeId >= GREATERTHAN && len(packet.subPackets) != 2:
+			return nil, 0, &packetError{pos, "comparison packet must have two sub-packets"}
+		}
+	}
+
+	return packet, consumed, nil
+}
+
+// intFromBits reads an integer starting at bit pos. Fixed width integers are n bits
+// long. Variable width integers are made of 5 bit groups, the first bit of which says
+// whether another group follows. It returns the value along with the number of bits used.
+func intFromBits(bits []int, pos, n int, variable bool) (int, int, error) {
+	ret := 0
+	consumed := 0
+
+	if variable {
+		cont := 1
+		for cont != 0 {
+			if pos+consumed+5 > len(bits) {
+				return 0, 0, &packetError{len(bits), "literal value runs past the end"}
+			}
+			if ret > math.MaxInt>>4 {
+				return 0, 0, &packetError{pos, "literal value is too large"}
+			}
+			group := bits[pos+consumed : pos+consumed+5]
+			cont = group[0]
+			for _, b := range group[1:] {
+				ret <<= 1
+				ret |= b & 1
+			}
+			consumed += 5
+		}
+	} else {
+		if pos+n > len(bits) {
+			return 0, 0, &packetError{len(bits), "length runs past the end"}
+		}
+		for _, b := range bits[pos : pos+n] {
+			ret <<= 1
+			ret |= b & 1
+		}
+		consumed = n
+	}
+
+	return ret, consumed, nil
+}
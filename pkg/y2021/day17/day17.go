@@ -0,0 +1,240 @@
+package day17
+
+import (
+	"github.com/ryderlewis/aoc2021/pkg/challenge"
+	"github.com/ryderlewis/aoc2021/pkg/parse"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+)
+
+// Puzzle is the target area
+type Puzzle struct {
+	MinX, MaxX int
+	MinY, MaxY int
+}
+
+type XVel struct {
+	startVel int
+	finalVal int
+	minSteps int
+	maxSteps int
+}
+
+type YVel struct {
+	startVel   int
+	highestVal int
+	minSteps   int
+	maxSteps   int
+}
+
+type Runner struct {
+	puzzle *Puzzle
+}
+
+var _ challenge.DailyChallenge = &Runner{}
+var _ challenge.PhasedChallenge = &Runner{}
+
+func init() {
+	challenge.Register(2021, 17, func() challenge.DailyChallenge {
+		return &Runner{}
+	})
+}
+
+func (r *Runner) Challenge1(input io.Reader) (string, error) {
+	if err := r.Load(input); err != nil {
+		return "", err
+	}
+
+	return r.Solve1()
+}
+
+func (r *Runner) Challenge2(input io.Reader) (string, error) {
+	if err := r.Load(input); err != nil {
+		return "", err
+	}
+
+	return r.Solve2()
+}
+
+func (r *Runner) Load(input io.Reader) (err error) {
+	r.puzzle, err = Parse(input)
+	return err
+}
+
+func (r *Runner) Solve1() (string, error) {
+	return Part1(r.puzzle)
+}
+
+func (r *Runner) Solve2() (string, error) {
+	return Part2(r.puzzle)
+}
+
+// Part1 finds the highest y position reached by any launch that ends up in the target
+func Part1(p *Puzzle) (string, error) {
+	// get x velocities
+	xvels := p.getXVels()
+
+	// now figure out all the starting y velocities that will get us there.
+	yvels := p.getYVels()
+
+	style := 0
+	for _, xvel := range xvels {
+		for _, yvel := range yvels {
+			if xvel.maxSteps < yvel.minSteps || xvel.minSteps > yvel.maxSteps {
+				continue
+			}
+			if style < yvel.highestVal {
+				style = yvel.highestVal
+			}
+		}
+	}
+
+	return strconv.Itoa(style), nil
+}
+
+// Part2 counts the launch velocities that end up in the target
+func Part2(p *Puzzle) (string, error) {
+	// get x velocities
+	xvels := p.getXVels()
+
+	// now figure out all the starting y velocities that will get us there.
+	yvels := p.getYVels()
+
+	count := 0
+	for _, xvel := range xvels {
+		for _, yvel := range yvels {
+			if xvel.maxSteps < yvel.minSteps || xvel.minSteps > yvel.maxSteps {
+				continue
+			}
+			count++
+		}
+	}
+
+	return strconv.Itoa(count), nil
+}
+
+func (p *Puzzle) getXVels() map[int]*XVel {
+	xvels := make(map[int]*XVel)
+
+	for v := 0; v <= p.MaxX; v++ {
+		finalVal := v * (v + 1) / 2
+		if finalVal < p.MinX {
+			continue
+		}
+
+		minSteps := 0
+		maxSteps := 0
+
+		currX := 0
+		for i := 0; i <= v; i++ {
+			if currX >= p.MinX && currX <= p.MaxX {
+				if minSteps == 0 {
+					minSteps = i
+				}
+				maxSteps = i
+			}
+
+			currX += v - i
+		}
+
+		if maxSteps > 0 {
+			if finalVal >= p.MinX && finalVal <= p.MaxX {
+				maxSteps = math.MaxInt
+			}
+
+			xvels[v] = &XVel{
+				startVel: v,
+				finalVal: finalVal,
+				minSteps: minSteps,
+				maxSteps: maxSteps,
+			}
+		}
+	}
+
+	return xvels
+}
+
+func (p *Puzzle) getYVels() map[int]*YVel {
+	// let's find all the possible steps that a particular x velocity can put us in the target range.
+	yvels := make(map[int]*YVel)
+
+	for v := p.MinY; v <= -p.MinY; v++ {
+		highestVal := 0
+		if v > 0 {
+			highestVal = v * (v + 1) / 2
+		}
+
+		minSteps := 0
+		maxSteps := 0
+
+		currY := 0
+		for i := 0; currY >= p.MinY; i++ {
+			if currY >= p.MinY && currY <= p.MaxY {
+				if minSteps == 0 {
+					minSteps = i
+				}
+				maxSteps = i
+			}
+
+			currY += v - i
+		}
+
+		if maxSteps > 0 {
+			yvels[v] = &YVel{
+				startVel:   v,
+				highestVal: highestVal,
+				minSteps:   minSteps,
+				maxSteps:   maxSteps,
+			}
+		}
+	}
+
+	return yvels
+}
+
+// maxDistance bounds how far away the target can be, as finding the velocities takes
+// time quadratic in the distance
+const maxDistance = 10000
+
+var targetSyntax = regexp.MustCompile(`^target area: x=(-?\d+)\.\.(-?\d+), y=(-?\d+)\.\.(-?\d+)$`)
+
+// Parse reads a target area such as "target area: x=20..30, y=-10..-5". The target
+// must be ahead of and below the probe.
+func Parse(input io.Reader) (*Puzzle, error) {
+	p := &Puzzle{}
+
+	scanner := parse.NewScanner(input)
+	line, err := scanner.Next()
+	if err != nil {
+		return nil, err
+	}
+	fields, err := line.Match(targetSyntax, `"target area: x=A..B, y=C..D"`)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, v := range []*int{&p.MinX, &p.MaxX, &p.MinY, &p.MaxY} {
+		if *v, err = fields[i].Int(); err != nil {
+			return nil, err
+		}
+	}
+	if p.MinX > p.MaxX {
+		return nil, fields[1].Errorf("%w: range ends before it starts", parse.ErrInteger)
+	}
+	if p.MinY > p.MaxY {
+		return nil, fields[3].Errorf("%w: range ends before it starts", parse.ErrInteger)
+	}
+	if p.MinX < 1 || p.MaxX > maxDistance {
+		return nil, fields[0].Errorf("%w: want x within 1..%d", parse.ErrInteger, maxDistance)
+	}
+	if p.MinY < -maxDistance || p.MaxY > -1 {
+		return nil, fields[2].Errorf("%w: want y within -%d..-1", parse.ErrInteger, maxDistance)
+	}
+
+	if err := scanner.End(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
@@ -0,0 +1,473 @@
+package day18
+
+import (
+	"fmt"
+	"github.com/ryderlewis/aoc2021/pkg/challenge"
+	"github.com/ryderlewis/aoc2021/pkg/parse"
+	"io"
+	"strconv"
+	"strings"
+)
+
+type Pair struct {
+	parent *Pair
+
+	leftVal  *int
+	leftPair *Pair
+
+	rightVal  *int
+	rightPair *Pair
+}
+
+// Puzzle is the list of snailfish numbers to add up
+type Puzzle struct {
+	Numbers []*Pair
+}
+
+type Runner struct {
+	puzzle *Puzzle
+}
+
+var _ challenge.DailyChallenge = &Runner{}
+var _ challenge.PhasedChallenge = &Runner{}
+var _ challenge.Explainer = &Runner{}
+
+func init() {
+	challenge.Register(2021, 18, func() challenge.DailyChallenge {
+		return &Runner{}
+	})
+}
+
+func (r *Runner) Challenge1(input io.Reader) (string, error) {
+	if err := r.Load(input); err != nil {
+		return "", err
+	}
+
+	return r.Solve1()
+}
+
+func (r *Runner) Challenge2(input io.Reader) (string, error) {
+	if err := r.Load(input); err != nil {
+		return "", err
+	}
+
+	return r.Solve2()
+}
+
+func (r *Runner) Challenge1Explain(input io.Reader, e *challenge.Explanation) (string, error) {
+	if err := r.Load(input); err != nil {
+		return "", err
+	}
+
+	return part1(r.puzzle, e)
+}
+
+func (r *Runner) Challenge2Explain(input io.Reader, e *challenge.Explanation) (string, error) {
+	if err := r.Load(input); err != nil {
+		return "", err
+	}
+
+	return part2(r.puzzle, e)
+}
+
+func (r *Runner) Load(input io.Reader) (err error) {
+	r.puzzle, err = Parse(input)
+	return err
+}
+
+func (r *Runner) Solve1() (string, error) {
+	return Part1(r.puzzle)
+}
+
+func (r *Runner) Solve2() (string, error) {
+	return Part2(r.puzzle)
+}
+
+// Part1 finds the magnitude of the sum of every number, in order. Reducing modifies
+// pairs in place, so the numbers are copied first.
+func Part1(p *Puzzle) (string, error) {
+	return part1(p, nil)
+}
+
+func part1(p *Puzzle, e *challenge.Explanation) (string, error) {
+	var sum *Pair
+	for _, q := range p.Numbers {
+		var step *challenge.Step
+		if sum == nil {
+			sum = q.copy()
+			step = e.Step("Start with %s", sum)
+		} else {
+			step = e.Step("Add %s", q)
+			sum = sum.combine(q.copy())
+			step.Detail("sum:     %s", sum)
+		}
+		sum.reduce(step)
+	}
+
+	e.Step("The magnitude of the final sum is %d", sum.magnitude())
+	return strconv.Itoa(sum.magnitude()), nil
+}
+
+// Part2 finds the largest magnitude of the sum of any two different numbers
+func Part2(p *Puzzle) (string, error) {
+	return part2(p, nil)
+}
+
+func part2(p *Puzzle, e *challenge.Explanation) (string, error) {
+	maxMagnitude := 0
+
+	// find results of all pairwise additions
+	for i, p1 := range p.Numbers {
+		for j, p2 := range p.Numbers {
+			if i == j {
+				continue
+			}
+			p1c := p1.copy()
+			p2c := p2.copy()
+			c := p1c.combine(p2c)
+
+			c.reduce(nil)
+			m := c.magnitude()
+			if m > maxMagnitude {
+				maxMagnitude = m
+				e.Step("Number %d + number %d has magnitude %d, the largest so far", i+1, j+1, m).Detail("%s", c)
+			}
+		}
+	}
+
+	e.Step("The largest magnitude is %d", maxMagnitude)
+	return strconv.Itoa(maxMagnitude), nil
+}
+
+func (p *Pair) combine(q *Pair) *Pair {
+	n := &Pair{
+		leftPair:  p,
+		rightPair: q,
+	}
+
+	p.parent = n
+	q.parent = n
+
+	return n
+}
+
+func (p *Pair) copy() *Pair {
+	c := &Pair{}
+
+	if p.leftVal != nil {
+		leftVal := *p.leftVal
+		c.leftVal = &leftVal
+	} else {
+		c.leftPair = p.leftPair.copy()
+		c.leftPair.parent = c
+	}
+
+	if p.rightVal != nil {
+		rightVal := *p.rightVal
+		c.rightVal = &rightVal
+	} else {
+		c.rightPair = p.rightPair.copy()
+		c.rightPair.parent = c
+	}
+
+	return c
+}
+
+// reduce explodes and splits p until it is reduced, adding each action to step
+func (p *Pair) reduce(step *challenge.Step) {
+	for {
+		if p.explode() {
+			step.Detail("explode: %s", p)
+			continue
+		}
+		if p.split() {
+			step.Detail("split:   %s", p)
+			continue
+		}
+		break
+	}
+}
+
+func (p *Pair) magnitude() int {
+	mag := 0
+
+	if p.leftVal != nil {
+		mag += *p.leftVal * 3
+	} else {
+		mag += p.leftPair.magnitude() * 3
+	}
+
+	if p.rightVal != nil {
+		mag += *p.rightVal * 2
+	} else {
+		mag += p.rightPair.magnitude() * 2
+	}
+
+	return mag
+}
+
+func (p *Pair) isLeft() bool {
+	return p.parent != nil && p.parent.leftPair == p
+}
+
+func (p *Pair) isRight() bool {
+	return p.parent != nil && p.parent.rightPair == p
+}
+
+func (p *Pair) isRoot() bool {
+	return p.parent == nil
+}
+
+func (p *Pair) nestCount() int {
+	if p.isRoot() {
+		return 0
+	}
+
+	return p.parent.nestCount() + 1
+}
+
+type TraverseInfo struct {
+	nodes []*TraverseNode
+}
+
+type TraverseNode struct {
+	val             *int
+	pair            *Pair
+	nestCount       int
+	isLeft, isRight bool
+}
+
+func (p *Pair) traverse(info *TraverseInfo) {
+	if p.leftVal != nil {
+		info.nodes = append(info.nodes, &TraverseNode{
+			val:       p.leftVal,
+			pair:      p,
+			nestCount: p.nestCount(),
+			isLeft:    true,
+		})
+	} else {
+		p.leftPair.traverse(info)
+	}
+
+	if p.rightVal != nil {
+		info.nodes = append(info.nodes, &TraverseNode{
+			val:       p.rightVal,
+			pair:      p,
+			nestCount: p.nestCount(),
+			isRight:   true,
+		})
+	} else {
+		p.rightPair.traverse(info)
+	}
+}
+
+func (p *Pair) explode() bool {
+	// find first pair in in-order traversal that is more than 4 levels nested
+	traverseInfo := &TraverseInfo{}
+	p.traverse(traverseInfo)
+
+	for i, t := range traverseInfo.nodes {
+		if t.nestCount == 4 {
+			// this one needs to be exploded!
+			if i > 0 {
+				*traverseInfo.nodes[i-1].val += *t.val
+			}
+			if i+2 < len(traverseInfo.nodes) {
+				*traverseInfo.nodes[i+2].val += *traverseInfo.nodes[i+1].val
+			}
+			zero := 0
+			parent := t.pair.parent
+			if t.pair.isLeft() {
+				parent.leftPair = nil
+				parent.leftVal = &zero
+			} else {
+				parent.rightPair = nil
+				parent.rightVal = &zero
+			}
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p *Pair) split() bool {
+	// find first pair in in-order traversal that is more than 9
+	traverseInfo := &TraverseInfo{}
+	p.traverse(traverseInfo)
+
+	for _, t := range traverseInfo.nodes {
+		if *t.val > 9 {
+			lval := *t.val / 2
+			rval := (*t.val + 1) / 2
+			newPair := &Pair{
+				parent:   t.pair,
+				leftVal:  &lval,
+				rightVal: &rval,
+			}
+			if t.isLeft {
+				t.pair.leftVal = nil
+				t.pair.leftPair = newPair
+			} else {
+				t.pair.rightVal = nil
+				t.pair.rightPair = newPair
+			}
+			return true
+		}
+	}
+
+	return false
+}
+
+// Parse reads one snailfish number per line
+func Parse(input io.Reader) (*Puzzle, error) {
+	p := &Puzzle{Numbers: make([]*Pair, 0)}
+	scanner := parse.NewScanner(input)
+	for scanner.Scan() {
+		pair, err := parsePair(scanner.Field())
+		if err != nil {
+			return nil, err
+		}
+		p.Numbers = append(p.Numbers, pair)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(p.Numbers) == 0 {
+		return nil, scanner.Truncated("a snailfish number")
+	}
+	return p, nil
+}
+
+func (p *Pair) String() string {
+	var b strings.Builder
+	b.WriteRune('[')
+
+	if p.leftVal != nil {
+		_, _ = fmt.Fprintf(&b, "%d,", *p.leftVal)
+	} else {
+		_, _ = fmt.Fprintf(&b, "%s,", p.leftPair)
+	}
+
+	if p.rightVal != nil {
+		_, _ = fmt.Fprintf(&b, "%d]", *p.rightVal)
+	} else {
+		_, _ = fmt.Fprintf(&b, "%s]", p.rightPair)
+	}
+
+	return b.String()
+}
+
+// parsePair reads a whole line as a snailfish number, such as "[[1,2],3]". Numbers
+// are never nested more than four pairs deep.
+func parsePair(line parse.Field) (*Pair, error) {
+	pp := &pairParser{line: line}
+
+	p, err := pp.pair(0)
+	if err != nil {
+		return nil, err
+	}
+	if pp.pos < len(line.Text) {
+		return nil, line.Slice(pp.pos, len(line.Text)).Errorf("%w: want the end of the line", parse.ErrSyntax)
+	}
+
+	return p, nil
+}
+
+// pairParser reads a snailfish number a character at a time
+type pairParser struct {
+	line parse.Field
+	pos  int
+}
+
+func (pp *pairParser) pair(depth int) (*Pair, error) {
+	start := pp.pos
+	if err := pp.expect('['); err != nil {
+		return nil, err
+	}
+	if depth == 4 {
+		return nil, pp.line.Slice(start, start+1).Errorf("%w: pair nested more than four deep", parse.ErrSyntax)
+	}
+
+	p := &Pair{}
+
+	// parse left side
+	if pp.peek() == '[' {
+		var err error
+		if p.leftPair, err = pp.pair(depth + 1); err != nil {
+			return nil, err
+		}
+		p.leftPair.parent = p
+	} else {
+		var err error
+		if p.leftVal, err = pp.number(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := pp.expect(','); err != nil {
+		return nil, err
+	}
+
+	// parse right side
+	if pp.peek() == '[' {
+		var err error
+		if p.rightPair, err = pp.pair(depth + 1); err != nil {
+			return nil, err
+		}
+		p.rightPair.parent = p
+	} else {
+		var err error
+		if p.rightVal, err = pp.number(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := pp.expect(']'); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+func (pp *pairParser) number() (*int, error) {
+	start := pp.pos
+	for pp.pos < len(pp.line.Text) && pp.line.Text[pp.pos] >= '0' && pp.line.Text[pp.pos] <= '9' {
+		pp.pos++
+	}
+	if start == pp.pos {
+		return nil, pp.unexpected("a number or '['")
+	}
+
+	val, err := pp.line.Slice(start, pp.pos).Int()
+	if err != nil {
+		return nil, err
+	}
+
+	return &val, nil
+}
+
+func (pp *pairParser) peek() byte {
+	if pp.pos < len(pp.line.Text) {
+		return pp.line.Text[pp.pos]
+	}
+	return 0
+}
+
+func (pp *pairParser) expect(c byte) error {
+	if pp.peek() != c {
+		return pp.unexpected(fmt.Sprintf("%q", c))
+	}
+	pp.pos++
+
+	return nil
+}
+
+// unexpected returns an error for the character at the current position
+func (pp *pairParser) unexpected(want string) error {
+	if pp.pos >= len(pp.line.Text) {
+		return pp.line.Slice(pp.pos, pp.pos).Errorf("%w: want %s", parse.ErrTruncated, want)
+	}
+	return pp.line.Slice(pp.pos, pp.pos+1).Errorf("%w: want %s", parse.ErrCharacter, want)
+}
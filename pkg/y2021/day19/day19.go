@@ -1,13 +1,14 @@
 package day19
 
 import (
-	"bufio"
+	"fmt"
 	"github.com/ryderlewis/aoc2021/pkg/challenge"
+	"github.com/ryderlewis/aoc2021/pkg/parse"
 	"io"
 	"math"
+	"regexp"
 	"sort"
 	"strconv"
-	"strings"
 )
 
 type Coordinate struct {
@@ -38,7 +39,7 @@ func (c *Coordinate) distance(d *Coordinate) float64 {
 // matchingCoordinates finds coordinates between two scanners that are the same,
 // determined by finding at least 11 overlapping distances to other coordinates within
 // the same scanner
-func (r *Runner) matchingCoordinates(s1, s2 *Scanner) map[*ScannerBeacon]*ScannerBeacon {
+func matchingCoordinates(s1, s2 *Scanner) map[*ScannerBeacon]*ScannerBeacon {
 	same := make(map[*ScannerBeacon]*ScannerBeacon)
 
 	for c1, f1 := range s1.distances {
@@ -71,8 +72,14 @@ func (r *Runner) matchingCoordinates(s1, s2 *Scanner) map[*ScannerBeacon]*Scanne
 	}
 
 	// somewhat ridiculous calculation, try to figure out the actual orientation of
-	// scanner 2 based on newly-discovered mapping of relative to global coordinates
-	s2.discoverOrientation()
+	// scanner 2 based on newly-discovered mapping of relative to global coordinates.
+	// Distances alone can't tell a reflection from a rotation, so there may be none.
+	if !s2.discoverOrientation() {
+		for _, c2 := range same {
+			c2.gCoord = nil
+		}
+		return nil
+	}
 	s2.updateGlobalPositions()
 
 	return same
@@ -86,9 +93,10 @@ func abs(i int) int {
 	}
 }
 
-func (s *Scanner) discoverOrientation() {
+// discoverOrientation reports whether any orientation fits the matched beacons
+func (s *Scanner) discoverOrientation() bool {
 	if s.orientation != nil || s.gCoord != nil {
-		return
+		return true
 	}
 
 	// find an orientation that produces a consistent global scanner location
@@ -131,13 +139,11 @@ func (s *Scanner) discoverOrientation() {
 		if consistent {
 			s.orientation = orientation
 			s.gCoord = gCoord
-			// fmt.Printf("%d: %#v\n", s.id, s.gCoord)
-			return
+			return true
 		}
 	}
 
-	// should not reach
-	panic("Could not find orientation :(")
+	return false
 }
 
 func (s *Scanner) updateGlobalPositions() {
@@ -191,46 +197,63 @@ func (s *Scanner) calcDistances() {
 	}
 }
 
+// Puzzle is the beacons seen by each scanner, relative to that scanner
+type Puzzle struct {
+	Scanners []*Scanner
+}
+
 type Runner struct {
-	scanners     []*Scanner
-	lastGlobalId int
+	puzzle *Puzzle
 }
 
 var _ challenge.DailyChallenge = &Runner{}
+var _ challenge.PhasedChallenge = &Runner{}
+
+func init() {
+	challenge.Register(2021, 19, func() challenge.DailyChallenge {
+		return &Runner{}
+	})
+}
 
 func (r *Runner) Challenge1(input io.Reader) (string, error) {
-	if err := r.readInput(input); err != nil {
+	if err := r.Load(input); err != nil {
 		return "", err
 	}
 
-	toCheck := make([]*Scanner, 1)
-	toCheck[0] = r.scanners[0]
-	checked := make(map[*Scanner]bool)
+	return r.Solve1()
+}
 
-	for len(toCheck) > 0 {
-		s1 := toCheck[0]
-		toCheck = toCheck[1:]
-		if _, alreadyChecked := checked[s1]; alreadyChecked {
-			continue
-		}
-		checked[s1] = true
+func (r *Runner) Challenge2(input io.Reader) (string, error) {
+	if err := r.Load(input); err != nil {
+		return "", err
+	}
 
-		for _, s2 := range r.scanners {
-			if _, alreadyChecked := checked[s2]; alreadyChecked {
-				continue
-			}
+	return r.Solve2()
+}
 
-			match := r.matchingCoordinates(s1, s2)
-			if match == nil {
-				continue
-			}
-			toCheck = append(toCheck, s2)
-		}
+func (r *Runner) Load(input io.Reader) (err error) {
+	r.puzzle, err = Parse(input)
+	return err
+}
+
+func (r *Runner) Solve1() (string, error) {
+	return Part1(r.puzzle)
+}
+
+func (r *Runner) Solve2() (string, error) {
+	return Part2(r.puzzle)
+}
+
+// Part1 counts the distinct beacons seen by all the scanners
+func Part1(p *Puzzle) (string, error) {
+	scanners, err := p.align()
+	if err != nil {
+		return "", err
 	}
 
 	// get all the global coordinates of all the beacons
 	beacons := make(map[Coordinate]bool)
-	for _, s := range r.scanners {
+	for _, s := range scanners {
 		for _, sb := range s.beacons {
 			beacons[*sb.gCoord] = true
 		}
@@ -239,13 +262,36 @@ func (r *Runner) Challenge1(input io.Reader) (string, error) {
 	return strconv.Itoa(len(beacons)), nil
 }
 
-func (r *Runner) Challenge2(input io.Reader) (string, error) {
-	if err := r.readInput(input); err != nil {
+// Part2 finds the largest manhattan distance between any two scanners
+func Part2(p *Puzzle) (string, error) {
+	scanners, err := p.align()
+	if err != nil {
 		return "", err
 	}
 
+	maxManhattan := 0
+	for _, s1 := range scanners {
+		for _, s2 := range scanners {
+			manhattan := abs(s1.gCoord.x-s2.gCoord.x) + abs(s1.gCoord.y-s2.gCoord.y) + abs(s1.gCoord.z-s2.gCoord.z)
+			if manhattan > maxManhattan {
+				maxManhattan = manhattan
+			}
+		}
+	}
+
+	return strconv.Itoa(maxManhattan), nil
+}
+
+// align works out the position and orientation of every scanner relative to scanner 0.
+// Alignment fills in the scanners as it goes, so it works on copies of them.
+func (p *Puzzle) align() ([]*Scanner, error) {
+	scanners := make([]*Scanner, len(p.Scanners))
+	for i, s := range p.Scanners {
+		scanners[i] = s.copy()
+	}
+
 	toCheck := make([]*Scanner, 1)
-	toCheck[0] = r.scanners[0]
+	toCheck[0] = scanners[0]
 	checked := make(map[*Scanner]bool)
 
 	for len(toCheck) > 0 {
@@ -256,12 +302,12 @@ func (r *Runner) Challenge2(input io.Reader) (string, error) {
 		}
 		checked[s1] = true
 
-		for _, s2 := range r.scanners {
+		for _, s2 := range scanners {
 			if _, alreadyChecked := checked[s2]; alreadyChecked {
 				continue
 			}
 
-			match := r.matchingCoordinates(s1, s2)
+			match := matchingCoordinates(s1, s2)
 			if match == nil {
 				continue
 			}
@@ -269,62 +315,100 @@ func (r *Runner) Challenge2(input io.Reader) (string, error) {
 		}
 	}
 
-	maxManhattan := 0
-	for _, s1 := range r.scanners {
-		for _, s2 := range r.scanners {
-			manhattan := abs(s1.gCoord.x - s2.gCoord.x) + abs(s1.gCoord.y - s2.gCoord.y) + abs(s1.gCoord.z - s2.gCoord.z)
-			if manhattan > maxManhattan {
-				maxManhattan = manhattan
-			}
+	for _, s := range scanners {
+		if s.gCoord == nil {
+			return nil, fmt.Errorf("Scanner %d doesn't overlap any other scanner", s.id)
 		}
 	}
 
-	return strconv.Itoa(maxManhattan), nil
+	return scanners, nil
 }
 
-func (r *Runner) readInput(input io.Reader) error {
-	scanner := bufio.NewScanner(input)
+// copy returns an unaligned copy of the scanner, except for scanner 0 which is the
+// global point of reference
+func (s *Scanner) copy() *Scanner {
+	c := &Scanner{id: s.id}
+	if c.id == 0 {
+		c.gCoord = &Coordinate{0, 0, 0}
+		c.orientation = orientations[0]
+	}
+
+	for _, sb := range s.beacons {
+		b := &ScannerBeacon{
+			scanner: c,
+			sCoord:  sb.sCoord,
+		}
+		if c.id == 0 {
+			b.gCoord = b.sCoord
+		}
+		c.beacons = append(c.beacons, b)
+	}
+	c.calcDistances()
+
+	return c
+}
+
+var scannerSyntax = regexp.MustCompile(`^--- scanner (\d+) ---$`)
+
+// Parse reads each scanner's beacons, starting with a line such as "--- scanner 0 ---".
+// Scanners are numbered in order from 0, and separated by blank lines.
+func Parse(input io.Reader) (*Puzzle, error) {
+	p := &Puzzle{}
+	scanner := parse.NewScanner(input)
 
 	var s *Scanner
 	for scanner.Scan() {
-		line := scanner.Text()
-		if strings.HasPrefix(line, "---") {
-			tokens := strings.Fields(line)
-			id, _ := strconv.Atoi(tokens[2])
+		line := scanner.Field()
+		if s == nil {
+			fields, err := line.Match(scannerSyntax, `"--- scanner N ---"`)
+			if err != nil {
+				return nil, err
+			}
+			id, err := fields[0].Int()
+			if err != nil {
+				return nil, err
+			}
+			if id != len(p.Scanners) {
+				return nil, fields[0].Errorf("%w: want scanner %d", parse.ErrSyntax, len(p.Scanners))
+			}
 			s = &Scanner{
 				id: id,
 			}
-			if id == 0 {
-				s.gCoord = &Coordinate{0, 0, 0}
+			p.Scanners = append(p.Scanners, s)
+		} else if len(line.Text) > 0 {
+			tokens, err := line.SplitN(",", 3)
+			if err != nil {
+				return nil, err
+			}
+			var coord [3]int
+			for i, token := range tokens {
+				if coord[i], err = token.Int(); err != nil {
+					return nil, err
+				}
 			}
-			r.scanners = append(r.scanners, s)
-		} else if len(line) > 0 {
-			tokens := strings.Split(line, ",")
-			x, _ := strconv.Atoi(tokens[0])
-			y, _ := strconv.Atoi(tokens[1])
-			z, _ := strconv.Atoi(tokens[2])
 			sb := &ScannerBeacon{
 				scanner: s,
 				sCoord: &Coordinate{
-					x: x,
-					y: y,
-					z: z,
+					x: coord[0],
+					y: coord[1],
+					z: coord[2],
 				},
 			}
-			if s.id == 0 {
-				s.orientation = orientations[0]
-				sb.gCoord = sb.sCoord
-			}
 
 			s.beacons = append(s.beacons, sb)
+		} else {
+			// blank line ends the scanner
+			s = nil
 		}
 	}
 
-	for _, s = range r.scanners {
-		s.calcDistances()
+	if err := scanner.Err(); err != nil {
+		return nil, err
 	}
-
-	return nil
+	if len(p.Scanners) == 0 {
+		return nil, scanner.Truncated(`"--- scanner 0 ---"`)
+	}
+	return p, nil
 }
 
 // var orientations
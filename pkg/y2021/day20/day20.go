@@ -0,0 +1,174 @@
+package day20
+
+import (
+	"github.com/ryderlewis/aoc2021/pkg/challenge"
+	"github.com/ryderlewis/aoc2021/pkg/grid"
+	"github.com/ryderlewis/aoc2021/pkg/parse"
+	"github.com/ryderlewis/aoc2021/pkg/viz"
+	"io"
+	"strconv"
+)
+
+// Puzzle is the image enhancement algorithm and the input image, where 1 is a lit pixel
+type Puzzle struct {
+	Algorithm [512]int
+	Image     *grid.Grid
+}
+
+// look shows the lit pixels as #
+var look = viz.Style{Max: 1, Chars: ".#"}
+
+// image is the state of the image as it is enhanced
+type image struct {
+	algo [512]int
+	grid *grid.Grid
+}
+
+type Runner struct {
+	puzzle *Puzzle
+}
+
+var _ challenge.DailyChallenge = &Runner{}
+var _ challenge.PhasedChallenge = &Runner{}
+var _ viz.Animator = &Runner{}
+
+func init() {
+	challenge.Register(2021, 20, func() challenge.DailyChallenge {
+		return &Runner{}
+	})
+}
+
+func (r *Runner) Challenge1(input io.Reader) (string, error) {
+	if err := r.Load(input); err != nil {
+		return "", err
+	}
+
+	return r.Solve1()
+}
+
+func (r *Runner) Challenge2(input io.Reader) (string, error) {
+	if err := r.Load(input); err != nil {
+		return "", err
+	}
+
+	return r.Solve2()
+}
+
+func (r *Runner) Challenge1Viz(input io.Reader, rec *viz.Recorder) (string, error) {
+	if err := r.Load(input); err != nil {
+		return "", err
+	}
+
+	return enhance(r.puzzle, 2, rec)
+}
+
+func (r *Runner) Challenge2Viz(input io.Reader, rec *viz.Recorder) (string, error) {
+	if err := r.Load(input); err != nil {
+		return "", err
+	}
+
+	return enhance(r.puzzle, 50, rec)
+}
+
+func (r *Runner) Load(input io.Reader) (err error) {
+	r.puzzle, err = Parse(input)
+	return err
+}
+
+func (r *Runner) Solve1() (string, error) {
+	return Part1(r.puzzle)
+}
+
+func (r *Runner) Solve2() (string, error) {
+	return Part2(r.puzzle)
+}
+
+// Part1 counts the lit pixels after enhancing the image twice
+func Part1(p *Puzzle) (string, error) {
+	return enhance(p, 2, nil)
+}
+
+// Part2 counts the lit pixels after enhancing the image fifty times
+func Part2(p *Puzzle) (string, error) {
+	return enhance(p, 50, nil)
+}
+
+// enhance counts the lit pixels after enhancing the image n times, recording the image
+// after every step
+func enhance(p *Puzzle, n int, rec *viz.Recorder) (string, error) {
+	r := p.image()
+	rec.Frame(r.grid, look)
+	for i := 0; i < n; i++ {
+		r.enhance((i % 2) & r.algo[0])
+		rec.Frame(r.grid, look)
+	}
+
+	return strconv.Itoa(r.lit()), nil
+}
+
+// image returns the input image, ready to be enhanced
+func (p *Puzzle) image() *image {
+	return &image{
+		algo: p.Algorithm,
+		grid: p.Image.Copy(),
+	}
+}
+
+// lit counts the lit pixels
+func (r *image) lit() int {
+	return r.grid.Count(func(v int) bool {
+		return v > 0
+	})
+}
+
+func (r *image) enhance(blink int) {
+	// pixels "outside" the image have the "blink" value
+	g := grid.New(r.grid.Width+2, r.grid.Height+2)
+
+	for _, pos := range g.Points() {
+		// make an algo index from the 3x3 square of the old image centered one up and
+		// left of pos, as the old image sits one down and right in the new one
+		index := 0
+		for dy := -2; dy <= 0; dy++ {
+			for dx := -2; dx <= 0; dx++ {
+				index = index<<1 | r.grid.GetOr(pos.Add(grid.Point{X: dx, Y: dy}), blink)
+			}
+		}
+
+		g.Set(pos, r.algo[index])
+	}
+
+	r.grid = g
+}
+
+// Parse reads the 512 character algorithm from the first line, then the image after a
+// blank line. Every row of the image must be the same length.
+func Parse(input io.Reader) (*Puzzle, error) {
+	p := &Puzzle{}
+
+	scanner := parse.NewScanner(input)
+	algoLine, err := scanner.Next()
+	if err != nil {
+		return nil, err
+	}
+	if err := algoLine.Only(".#"); err != nil {
+		return nil, err
+	}
+	if len(algoLine.Text) != len(p.Algorithm) {
+		return nil, algoLine.Errorf("%w: want %d characters, got %d", parse.ErrSyntax, len(p.Algorithm), len(algoLine.Text))
+	}
+	for i, c := range []rune(algoLine.Text) {
+		if c == '#' {
+			p.Algorithm[i] = 1
+		}
+	}
+
+	if err := scanner.Blank(); err != nil {
+		return nil, err
+	}
+
+	if p.Image, err = grid.Read(scanner, ".#", "a row of the image"); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
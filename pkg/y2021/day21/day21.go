@@ -0,0 +1,278 @@
+package day21
+
+import (
+	"github.com/ryderlewis/aoc2021/pkg/challenge"
+	"github.com/ryderlewis/aoc2021/pkg/parse"
+	"io"
+	"regexp"
+	"strconv"
+)
+
+type Player struct {
+	pos   int
+	score int
+}
+
+type DeterministicDie struct {
+	rolls   int
+	nextVal int
+}
+
+func (d *DeterministicDie) Roll(times int) int {
+	val := 0
+	for i := 0; i < times; i++ {
+		d.rolls++
+		val += d.nextVal
+		d.nextVal++
+		if d.nextVal > 100 {
+			d.nextVal = 1
+		}
+	}
+	return val
+}
+
+// Puzzle is each player's starting position, counting from 0
+type Puzzle struct {
+	Start [2]int
+}
+
+// game is the state of a game in progress
+type game struct {
+	p1, p2 *Player
+	board  [10]int
+}
+
+type Runner struct {
+	puzzle *Puzzle
+}
+
+var _ challenge.DailyChallenge = &Runner{}
+var _ challenge.PhasedChallenge = &Runner{}
+var _ challenge.Explainer = &Runner{}
+
+func init() {
+	challenge.Register(2021, 21, func() challenge.DailyChallenge {
+		return &Runner{}
+	})
+}
+
+func (r *Runner) Challenge1(input io.Reader) (string, error) {
+	if err := r.Load(input); err != nil {
+		return "", err
+	}
+
+	return r.Solve1()
+}
+
+func (r *Runner) Challenge2(input io.Reader) (string, error) {
+	if err := r.Load(input); err != nil {
+		return "", err
+	}
+
+	return r.Solve2()
+}
+
+func (r *Runner) Challenge1Explain(input io.Reader, e *challenge.Explanation) (string, error) {
+	if err := r.Load(input); err != nil {
+		return "", err
+	}
+
+	return part1(r.puzzle, e)
+}
+
+func (r *Runner) Challenge2Explain(input io.Reader, e *challenge.Explanation) (string, error) {
+	if err := r.Load(input); err != nil {
+		return "", err
+	}
+
+	return part2(r.puzzle, e)
+}
+
+func (r *Runner) Load(input io.Reader) (err error) {
+	r.puzzle, err = Parse(input)
+	return err
+}
+
+func (r *Runner) Solve1() (string, error) {
+	return Part1(r.puzzle)
+}
+
+func (r *Runner) Solve2() (string, error) {
+	return Part2(r.puzzle)
+}
+
+// Part1 plays with the deterministic die, and scores the losing player
+func Part1(p *Puzzle) (string, error) {
+	return part1(p, nil)
+}
+
+func part1(p *Puzzle, e *challenge.Explanation) (string, error) {
+	r := p.game()
+
+	die := &DeterministicDie{
+		nextVal: 1,
+	}
+
+	for {
+		r.p1.pos += die.Roll(3)
+		r.p1.pos %= len(r.board)
+		r.p1.score += r.board[r.p1.pos]
+		if r.p1.score >= 1000 {
+			break
+		}
+
+		r.p2.pos += die.Roll(3)
+		r.p2.pos %= len(r.board)
+		r.p2.score += r.board[r.p2.pos]
+		if r.p2.score >= 1000 {
+			break
+		}
+	}
+
+	losingScore := r.p1.score
+	if losingScore >= 1000 {
+		losingScore = r.p2.score
+		e.Step("Player 1 reaches %d points first, after %d rolls", r.p1.score, die.rolls)
+	} else {
+		e.Step("Player 2 reaches %d points first, after %d rolls", r.p2.score, die.rolls)
+	}
+	e.Step("The losing score of %d times %d rolls is %d", losingScore, die.rolls, losingScore*die.rolls)
+
+	return strconv.Itoa(die.rolls * losingScore), nil
+}
+
+type Universe struct {
+	positions [2]int
+	scores    [2]int
+}
+
+// Part2 plays with the dirac die, and counts the universes the more successful player
+// wins in
+func Part2(p *Puzzle) (string, error) {
+	return part2(p, nil)
+}
+
+func part2(p *Puzzle, e *challenge.Explanation) (string, error) {
+	return strconv.FormatUint(diracWins(p, 21, e), 10), nil
+}
+
+// DiracWins plays with the dirac die until one player reaches the target score, and
+// counts the universes the more successful player wins in
+func DiracWins(p *Puzzle, target int) uint64 {
+	return diracWins(p, target, nil)
+}
+
+func diracWins(p *Puzzle, target int, e *challenge.Explanation) uint64 {
+	r := p.game()
+
+	// universes is a count of possible universes
+	universes := make(map[Universe]uint64)
+
+	// and the starting universe is a single universe where both players have a score of 0
+	universes[Universe{
+		positions: [2]int{r.p1.pos, r.p2.pos},
+	}] = 1
+	currentPlayer, turn := 0, 0
+	var wins [2]uint64
+
+	// dirac die can have the following rolls:
+	rolls := map[int]int{
+		3: 1,
+		4: 3,
+		5: 6,
+		6: 7,
+		7: 6,
+		8: 3,
+		9: 1,
+	}
+
+	for len(universes) > 0 {
+		nextUniverses := make(map[Universe]uint64)
+
+		for u, c := range universes {
+			for roll, count := range rolls {
+				nextU := u
+				nextU.positions[currentPlayer] += roll
+				nextU.positions[currentPlayer] %= len(r.board)
+				nextU.scores[currentPlayer] += r.board[nextU.positions[currentPlayer]]
+
+				if nextU.scores[currentPlayer] >= target {
+					wins[currentPlayer] += c * uint64(count)
+				} else {
+					nextUniverses[nextU] += c * uint64(count)
+				}
+			}
+		}
+
+		universes = nextUniverses
+		turn++
+		if e != nil {
+			var playing uint64
+			for _, c := range universes {
+				playing += c
+			}
+			e.Step("Turn %d, player %d: player 1 has won in %d universes and player 2 in %d, and %d games go on",
+				turn, currentPlayer+1, wins[0], wins[1], playing)
+		}
+		currentPlayer++
+		currentPlayer %= 2
+	}
+
+	bestScore := wins[0]
+	if wins[1] > bestScore {
+		bestScore = wins[1]
+	}
+	e.Step("The most universes won by one player is %d", bestScore)
+
+	return bestScore
+}
+
+// game sets up a new game at the starting positions
+func (p *Puzzle) game() *game {
+	r := &game{
+		p1: &Player{pos: p.Start[0]},
+		p2: &Player{pos: p.Start[1]},
+	}
+
+	for i := 0; i < 10; i++ {
+		r.board[i] = i + 1
+	}
+
+	return r
+}
+
+var playerSyntax = regexp.MustCompile(`^Player (\d+) starting position: (\d+)$`)
+
+// Parse reads each player's starting position, such as "Player 1 starting position: 4"
+func Parse(input io.Reader) (*Puzzle, error) {
+	p := &Puzzle{}
+
+	scanner := parse.NewScanner(input)
+	for i := 0; i < 2; i++ {
+		line, err := scanner.Next()
+		if err != nil {
+			return nil, err
+		}
+		fields, err := line.Match(playerSyntax, `"Player N starting position: P"`)
+		if err != nil {
+			return nil, err
+		}
+
+		if player, err := fields[0].Int(); err != nil || player != i+1 {
+			return nil, fields[0].Errorf("%w: want player %d", parse.ErrSyntax, i+1)
+		}
+		start, err := fields[1].Int()
+		if err != nil {
+			return nil, err
+		}
+		if start < 1 || start > 10 {
+			return nil, fields[1].Errorf("%w: want a position from 1 to 10", parse.ErrInteger)
+		}
+		p.Start[i] = start - 1
+	}
+
+	if err := scanner.End(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
@@ -0,0 +1,223 @@
+package day22
+
+import (
+	"github.com/ryderlewis/aoc2021/pkg/challenge"
+	"github.com/ryderlewis/aoc2021/pkg/parse"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+)
+
+// Region is a cuboid, including the cubes on its bounds, and whether a step turns it on
+type Region struct {
+	MinX, MaxX, MinY, MaxY, MinZ, MaxZ int
+	On                                 bool
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func (r Region) volume() int {
+	return max(r.MaxX-r.MinX+1, 0) * max(r.MaxY-r.MinY+1, 0) * max(r.MaxZ-r.MinZ+1, 0)
+}
+
+// intersects tests to see if region r intersects region o
+func (r Region) intersects(o Region) bool {
+	if r.MaxX < o.MinX || r.MinX > o.MaxX {
+		return false
+	}
+	if r.MaxY < o.MinY || r.MinY > o.MaxY {
+		return false
+	}
+	if r.MaxZ < o.MinZ || r.MinZ > o.MaxZ {
+		return false
+	}
+	return true
+}
+
+// spawn new regions from r that are non overlapping with o
+func (r Region) nonOverlapping(o Region) []Region {
+	ret := make([]Region, 0)
+
+	if !r.intersects(o) {
+		ret = append(ret, r)
+		return ret
+	}
+
+	overlappingMinX := max(r.MinX, o.MinX)
+	overlappingMaxX := min(r.MaxX, o.MaxX)
+	overlappingMinY := max(r.MinY, o.MinY)
+	overlappingMaxY := min(r.MaxY, o.MaxY)
+	overlappingMinZ := max(r.MinZ, o.MinZ)
+	overlappingMaxZ := min(r.MaxZ, o.MaxZ)
+
+	// add up to six regions not including the regions that overlap with o
+	if r.MinX < overlappingMinX {
+		ret = append(ret, Region{r.MinX, overlappingMinX - 1, r.MinY, r.MaxY, r.MinZ, r.MaxZ, r.On})
+	}
+	if r.MaxX > overlappingMaxX {
+		ret = append(ret, Region{overlappingMaxX + 1, r.MaxX, r.MinY, r.MaxY, r.MinZ, r.MaxZ, r.On})
+	}
+	if r.MinY < overlappingMinY {
+		ret = append(ret, Region{overlappingMinX, overlappingMaxX, r.MinY, overlappingMinY - 1, r.MinZ, r.MaxZ, r.On})
+	}
+	if r.MaxY > overlappingMaxY {
+		ret = append(ret, Region{overlappingMinX, overlappingMaxX, overlappingMaxY + 1, r.MaxY, r.MinZ, r.MaxZ, r.On})
+	}
+	if r.MinZ < overlappingMinZ {
+		ret = append(ret, Region{overlappingMinX, overlappingMaxX, overlappingMinY, overlappingMaxY, r.MinZ, overlappingMinZ - 1, r.On})
+	}
+	if r.MaxZ > overlappingMaxZ {
+		ret = append(ret, Region{overlappingMinX, overlappingMaxX, overlappingMinY, overlappingMaxY, overlappingMaxZ + 1, r.MaxZ, r.On})
+	}
+
+	return ret
+}
+
+// Puzzle is the list of reboot steps, in order
+type Puzzle struct {
+	Steps []Region
+}
+
+type Runner struct {
+	puzzle *Puzzle
+}
+
+var _ challenge.DailyChallenge = &Runner{}
+var _ challenge.PhasedChallenge = &Runner{}
+
+func init() {
+	challenge.Register(2021, 22, func() challenge.DailyChallenge {
+		return &Runner{}
+	})
+}
+
+func (r *Runner) Challenge1(input io.Reader) (string, error) {
+	if err := r.Load(input); err != nil {
+		return "", err
+	}
+
+	return r.Solve1()
+}
+
+func (r *Runner) Challenge2(input io.Reader) (string, error) {
+	if err := r.Load(input); err != nil {
+		return "", err
+	}
+
+	return r.Solve2()
+}
+
+func (r *Runner) Load(input io.Reader) (err error) {
+	r.puzzle, err = Parse(input)
+	return err
+}
+
+func (r *Runner) Solve1() (string, error) {
+	return Part1(r.puzzle)
+}
+
+func (r *Runner) Solve2() (string, error) {
+	return Part2(r.puzzle)
+}
+
+// Part1 counts the cubes left on within -50..50 in each dimension
+func Part1(p *Puzzle) (string, error) {
+	regions := make([]Region, len(p.Steps), len(p.Steps)+6)
+	copy(regions, p.Steps)
+
+	// turn off anything outside of -50..50 in each dimension
+	regions = append(regions, Region{math.MinInt, -51, math.MinInt, math.MaxInt, math.MinInt, math.MaxInt, false})
+	regions = append(regions, Region{51, math.MaxInt, math.MinInt, math.MaxInt, math.MinInt, math.MaxInt, false})
+	regions = append(regions, Region{math.MinInt, math.MaxInt, math.MinInt, -51, math.MinInt, math.MaxInt, false})
+	regions = append(regions, Region{math.MinInt, math.MaxInt, 51, math.MaxInt, math.MinInt, math.MaxInt, false})
+	regions = append(regions, Region{math.MinInt, math.MaxInt, math.MinInt, math.MaxInt, math.MinInt, -51, false})
+	regions = append(regions, Region{math.MinInt, math.MaxInt, math.MinInt, math.MaxInt, 51, math.MaxInt, false})
+
+	return strconv.Itoa(reboot(regions)), nil
+}
+
+// Part2 counts the cubes left on after every step
+func Part2(p *Puzzle) (string, error) {
+	return strconv.Itoa(reboot(p.Steps)), nil
+}
+
+// reboot runs the reboot steps and returns a count of on cubes
+func reboot(regions []Region) int {
+	on := make([]Region, 0)
+
+	for _, reg := range regions {
+		nextOn := make([]Region, 0)
+
+		for _, reg2 := range on {
+			for _, reg3 := range reg2.nonOverlapping(reg) {
+				nextOn = append(nextOn, reg3)
+			}
+		}
+
+		if reg.On {
+			nextOn = append(nextOn, reg)
+		}
+
+		on = nextOn
+	}
+
+	onCount := 0
+	for _, reg := range on {
+		onCount += reg.volume()
+	}
+
+	return onCount
+}
+
+var stepSyntax = regexp.MustCompile(`^(on|off) x=(-?\d+)\.\.(-?\d+),y=(-?\d+)\.\.(-?\d+),z=(-?\d+)\.\.(-?\d+)$`)
+
+// Parse reads one reboot step per line, such as "on x=10..12,y=10..12,z=10..12"
+func Parse(input io.Reader) (*Puzzle, error) {
+	p := &Puzzle{}
+	scanner := parse.NewScanner(input)
+
+	for scanner.Scan() {
+		fields, err := scanner.Field().Match(stepSyntax, `"on x=A..B,y=C..D,z=E..F" or "off ..."`)
+		if err != nil {
+			return nil, err
+		}
+
+		var bounds [6]int
+		for i, f := range fields[1:] {
+			if bounds[i], err = f.Int(); err != nil {
+				return nil, err
+			}
+			if i%2 == 1 && bounds[i] < bounds[i-1] {
+				return nil, f.Errorf("%w: range ends before it starts", parse.ErrInteger)
+			}
+		}
+
+		p.Steps = append(p.Steps, Region{
+			MinX: bounds[0],
+			MaxX: bounds[1],
+			MinY: bounds[2],
+			MaxY: bounds[3],
+			MinZ: bounds[4],
+			MaxZ: bounds[5],
+			On:   fields[0].Text == "on",
+		})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
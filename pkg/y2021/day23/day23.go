@@ -0,0 +1,633 @@
+package day23
+
+import (
+	"context"
+	"fmt"
+	"github.com/ryderlewis/aoc2021/pkg/challenge"
+	"github.com/ryderlewis/aoc2021/pkg/grid"
+	"github.com/ryderlewis/aoc2021/pkg/parse"
+	"github.com/ryderlewis/aoc2021/pkg/search"
+	"github.com/ryderlewis/aoc2021/pkg/trace"
+	"github.com/ryderlewis/aoc2021/pkg/viz"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type Amphipod byte
+
+func (a Amphipod) String() string {
+	if a == EMPTY {
+		return "."
+	} else {
+		return string(a)
+	}
+}
+
+const (
+	EMPTY = Amphipod(0)
+	DIRT = Amphipod('#')
+	A = Amphipod('A')
+	B = Amphipod('B')
+	C = Amphipod('C')
+	D = Amphipod('D')
+)
+
+type State [27]Amphipod
+
+type Move struct {
+	amphipod Amphipod
+	from, to, distance, energy int
+}
+
+type AmphipodData struct {
+	hallIndex int
+	destIndexes []int
+	energy int
+}
+
+// Puzzle is the starting arrangement of the burrow, with both rooms only two deep
+type Puzzle struct {
+	Start State
+}
+
+type Runner struct {
+	puzzle *Puzzle
+	trace  *trace.Tracer
+}
+
+// burrow holds the layout of the burrow, as a graph of states to search where each move
+// costs the energy it takes
+type burrow struct {
+	amphipodData    map[Amphipod]*AmphipodData
+	roomHallIndexes map[int]int
+	trace           *trace.Tracer
+}
+
+var _ search.Graph = &burrow{}
+
+// Neighbors returns the states reached by each valid move from s
+func (r *burrow) Neighbors(s search.State) []search.Edge {
+	state := s.(State)
+	var edges []search.Edge
+
+	for i := range state {
+		// find legal moves for amphipod a, at position i
+		for _, move := range r.validMoves(&state, i) {
+			newState := state // makes a copy of state
+			newState[move.from], newState[move.to] = newState[move.to], newState[move.from]
+			edges = append(edges, search.Edge{To: newState, Cost: move.energy})
+		}
+	}
+
+	return edges
+}
+
+// Goal reports whether every amphipod is in its own room
+func (r *burrow) Goal(s search.State) bool {
+	state := s.(State)
+	return r.isFinal(&state)
+}
+
+// leastEnergy estimates the energy left to spend, as if every amphipod not yet settled
+// at the bottom of its own room could walk to the door of its room through any others in
+// the way, and then the amphipods of each kind fill the top of their room
+func (r *burrow) leastEnergy(s search.State) int {
+	state := s.(State)
+	energy := 0
+	var entering [4]int // by kind, from A
+
+	for i, a := range state {
+		if a == EMPTY || a == DIRT {
+			continue
+		}
+		data := r.amphipodData[a]
+
+		var steps int
+		if i <= 10 {
+			steps = abs(i - data.hallIndex)
+		} else {
+			roomHallIndex := r.roomHallIndexes[i]
+			if roomHallIndex == data.hallIndex && r.settled(&state, i) {
+				continue
+			}
+
+			// leaving its own room, it has to step aside and come back
+			depth := (i-11)%4 + 1
+			steps = depth + abs(roomHallIndex-data.hallIndex)
+			if roomHallIndex == data.hallIndex {
+				steps = depth + 2
+			}
+		}
+		entering[a-A]++
+		energy += steps * data.energy
+	}
+
+	// the amphipods entering each room go 1, 2, ... steps in
+	for i, n := range entering {
+		energy += n * (n + 1) / 2 * r.amphipodData[A+Amphipod(i)].energy
+	}
+
+	return energy
+}
+
+// settled reports whether the amphipod at i is in its own room, with only the same kind
+// below it
+func (r *burrow) settled(s *State, i int) bool {
+	for j := i; j <= 26 && r.roomHallIndexes[j] == r.roomHallIndexes[i]; j++ {
+		if s[j] != s[i] && s[j] != DIRT {
+			return false
+		}
+	}
+
+	return true
+}
+
+func abs(i int) int {
+	if i < 0 {
+		return -i
+	}
+	return i
+}
+
+func (r *burrow) validMoves(s *State, i int) []*Move {
+	if s[i] == EMPTY || s[i] == DIRT {
+		return nil
+	}
+
+	a := s[i]
+	data := r.amphipodData[s[i]]
+
+	var moves []*Move
+
+	if 0 <= i && i <= 10 {
+		// amphipod is in the hall. only legal moves are to go to its final destination
+		var dest, destDepth int
+		for depth, d := range data.destIndexes {
+			switch s[d] {
+			case a:
+				// this is fine
+			case DIRT:
+				// this is fine
+			case EMPTY:
+				dest = d
+				destDepth = depth + 1
+			default:
+				// there is a conflict, cannot move to this destination now
+				return nil
+			}
+		}
+
+		// see if there's a clear path from the current location to the destination index
+		hallIndexes := []int{i, r.roomHallIndexes[dest]}
+		sort.Ints(hallIndexes)
+		for j := hallIndexes[0]; j <= hallIndexes[1]; j++ {
+			if i == j {
+				continue
+			}
+			if s[j] != EMPTY {
+				return nil // path is blocked
+			}
+		}
+
+		steps := hallIndexes[1] - hallIndexes[0] + destDepth
+		return []*Move{
+			{
+				amphipod: a,
+				from: i,
+				to: dest,
+				distance: steps,
+				energy: data.energy * steps,
+			},
+		}
+	} else {
+		// amphipod is in a room. first, see which room it's in.
+		currRoomHallIndex := r.roomHallIndexes[i]
+		destRoomHallIndex := data.hallIndex
+		alreadyHome := currRoomHallIndex == destRoomHallIndex
+
+		// if amphipod is in the right room, and there are no conflicts deeper in the room, then peace out.
+		if alreadyHome {
+			// see if there are any other amphipod types deeper in this room. If so, we need to move to let them out
+			conflict := false
+			for j := i+1; r.roomHallIndexes[j] == currRoomHallIndex; j++ {
+				if s[j] != a && s[j] != DIRT {
+					conflict = true
+					break
+				}
+			}
+			if !conflict {
+				return nil // no conflicting amphipods deeper in the room. this one can stay where it's at
+			}
+		}
+
+		// amphipod should leave the room. make sure it isn't blocked
+		sourceDepth := 1
+		for j := i-1; r.roomHallIndexes[j] == currRoomHallIndex; j-- {
+			if s[j] != EMPTY {
+				return nil // amphipod is blocked
+			}
+			sourceDepth++
+		}
+
+		// amphipod is in a room, but it needs to move out for now
+		// first of all, see if the amphipod can go straight to its final destination. this is
+		// the ideal case
+		homeIsGood := true
+		var homeDest, destDepth int
+		for depth, d := range data.destIndexes {
+			switch s[d] {
+			case EMPTY:
+				homeDest = d
+				destDepth = depth + 1
+			case DIRT:
+				// this is fine
+			case a:
+				// this is fine
+			default:
+				// there is a conflict, cannot move to this destination now
+				homeIsGood = false
+			}
+		}
+
+		if homeIsGood {
+			// see if there is a clear path to home. If not, then home is not good
+			hallIndexes := []int{currRoomHallIndex, destRoomHallIndex}
+			sort.Ints(hallIndexes)
+
+			for j := hallIndexes[0]; j <= hallIndexes[1]; j++ {
+				if s[j] != EMPTY {
+					homeIsGood = false
+					break
+				}
+			}
+
+			if homeIsGood {
+				// number of steps is all steps in the hall, plus destDepth steps into the room
+				steps := hallIndexes[1] - hallIndexes[0] + sourceDepth + destDepth
+
+				return []*Move{
+					{
+						amphipod: a,
+						from: i,
+						to: homeDest,
+						distance: steps,
+						energy: data.energy * steps,
+					},
+				}
+			}
+		}
+
+		// home is not good. So now we need to figure out where in the hall this amphipod can go.
+		for j := currRoomHallIndex-1; j >= 0 && s[j] == EMPTY; j-- {
+			if j == 2 || j == 4 || j == 6 || j == 8 {
+				// can't stop outside a room
+				continue
+			}
+
+			steps := currRoomHallIndex - j + sourceDepth
+			moves = append(moves, &Move{
+				amphipod: a,
+				from: i,
+				to: j,
+				distance: steps,
+				energy: data.energy * steps,
+			})
+		}
+
+		for j := currRoomHallIndex+1; j <= 10 && s[j] == EMPTY; j++ {
+			if j == 2 || j == 4 || j == 6 || j == 8 {
+				// can't stop outside a room
+				continue
+			}
+
+			steps := j - currRoomHallIndex + sourceDepth
+			moves = append(moves, &Move{
+				amphipod: a,
+				from: i,
+				to: j,
+				distance: steps,
+				energy: data.energy * steps,
+			})
+		}
+	}
+
+	return moves
+}
+
+func (r *burrow) isFinal(s *State) bool {
+	for a, d := range r.amphipodData {
+		for _, i := range d.destIndexes {
+			if a != s[i] && s[i] != DIRT {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func (r *Runner) Challenge1(input io.Reader) (string, error) {
+	return r.Challenge1Context(context.Background(), input)
+}
+
+func (r *Runner) Challenge1Context(ctx context.Context, input io.Reader) (string, error) {
+	if err := r.Load(input); err != nil {
+		return "", err
+	}
+
+	return part1(ctx, r.puzzle, nil, r.trace)
+}
+
+func (r *Runner) Challenge2(input io.Reader) (string, error) {
+	return r.Challenge2Context(context.Background(), input)
+}
+
+func (r *Runner) Challenge2Context(ctx context.Context, input io.Reader) (string, error) {
+	if err := r.Load(input); err != nil {
+		return "", err
+	}
+
+	return part2(ctx, r.puzzle, nil, r.trace)
+}
+
+func (r *Runner) Challenge1Viz(input io.Reader, rec *viz.Recorder) (string, error) {
+	if err := r.Load(input); err != nil {
+		return "", err
+	}
+
+	return part1(context.Background(), r.puzzle, rec, r.trace)
+}
+
+func (r *Runner) Challenge2Viz(input io.Reader, rec *viz.Recorder) (string, error) {
+	if err := r.Load(input); err != nil {
+		return "", err
+	}
+
+	return part2(context.Background(), r.puzzle, rec, r.trace)
+}
+
+func (r *Runner) Load(input io.Reader) (err error) {
+	r.puzzle, err = Parse(input)
+	return err
+}
+
+func (r *Runner) SetTracer(t *trace.Tracer) {
+	r.trace = t
+}
+
+func (r *Runner) Solve1() (string, error) {
+	return part1(context.Background(), r.puzzle, nil, r.trace)
+}
+
+func (r *Runner) Solve2() (string, error) {
+	return part2(context.Background(), r.puzzle, nil, r.trace)
+}
+
+// Part1 finds the least energy needed to organize the amphipods
+func Part1(p *Puzzle) (string, error) {
+	return part1(context.Background(), p, nil, nil)
+}
+
+// Part2 finds the least energy needed to organize the amphipods once the folded part of
+// the diagram is added, making each room four deep
+func Part2(p *Puzzle) (string, error) {
+	return part2(context.Background(), p, nil, nil)
+}
+
+func part1(ctx context.Context, p *Puzzle, rec *viz.Recorder, t *trace.Tracer) (string, error) {
+	r := newBurrow()
+	r.trace = t
+	start := p.Start
+
+	return r.solve(ctx, &start, rec)
+}
+
+func part2(ctx context.Context, p *Puzzle, rec *viz.Recorder, t *trace.Tracer) (string, error) {
+	r := newBurrow()
+	r.trace = t
+	start := p.Start
+
+	start[r.amphipodData[A].destIndexes[3]] = start[r.amphipodData[A].destIndexes[1]]
+	start[r.amphipodData[A].destIndexes[1]] = D
+	start[r.amphipodData[A].destIndexes[2]] = D
+
+	start[r.amphipodData[B].destIndexes[3]] = start[r.amphipodData[B].destIndexes[1]]
+	start[r.amphipodData[B].destIndexes[1]] = C
+	start[r.amphipodData[B].destIndexes[2]] = B
+
+	start[r.amphipodData[C].destIndexes[3]] = start[r.amphipodData[C].destIndexes[1]]
+	start[r.amphipodData[C].destIndexes[1]] = B
+	start[r.amphipodData[C].destIndexes[2]] = A
+
+	start[r.amphipodData[D].destIndexes[3]] = start[r.amphipodData[D].destIndexes[1]]
+	start[r.amphipodData[D].destIndexes[1]] = A
+	start[r.amphipodData[D].destIndexes[2]] = C
+
+	return r.solve(ctx, &start, rec)
+}
+
+// solve finds the least energy needed to organize the amphipods from start, recording
+// the burrow after each move along the way
+func (r *burrow) solve(ctx context.Context, start *State, rec *viz.Recorder) (string, error) {
+	r.trace.Infof("Starting from\n%s", r.frame(*start).Render(chars))
+
+	found, err := search.AStar(ctx, r, *start, r.leastEnergy)
+	if ctx.Err() != nil {
+		return "", challenge.Aborted(ctx)
+	}
+	if err != nil {
+		return "", fmt.Errorf("Amphipods can't be organized: %w", err)
+	}
+	r.record(rec, found.Path)
+
+	r.trace.Infof("Organized in %d moves, exploring %d states", len(found.Path)-1, found.Expanded)
+	if r.trace.Enabled(trace.Debug) {
+		for i, s := range found.Path[1:] {
+			r.trace.Debugf("Move %d\n%s", i+1, r.frame(s.(State)).Render(chars))
+		}
+	}
+
+	return strconv.Itoa(found.Cost), nil
+}
+
+// chars are the characters of the burrow as recorded: outside it, its walls, then empty
+// spaces and each kind of amphipod
+const chars = " #.ABCD"
+
+// look shows the burrow as in the diagram, highlighting each amphipod as it moves
+var look = viz.Style{Max: len(chars) - 1, Chars: chars}
+
+// record adds a frame of the burrow for each state along path
+func (r *burrow) record(rec *viz.Recorder, path []search.State) {
+	if rec == nil {
+		return
+	}
+
+	for _, s := range path {
+		rec.Frame(r.frame(s.(State)), look)
+	}
+}
+
+// frame draws the burrow in state s, laid out as in the diagram
+func (r *burrow) frame(s State) *grid.Grid {
+	rooms := r.amphipodData[A].destIndexes
+	depth := 0
+	for _, i := range rooms {
+		if s[i] != DIRT {
+			depth++
+		}
+	}
+
+	g := grid.New(13, depth+3)
+	wall := strings.IndexByte(chars, '#')
+	for x := 0; x < g.Width; x++ {
+		g.Set(grid.Point{X: x, Y: 0}, wall)
+		if x >= 2 && x <= 10 {
+			g.Set(grid.Point{X: x, Y: depth + 2}, wall)
+		}
+	}
+
+	g.Set(grid.Point{X: 0, Y: 1}, wall)
+	g.Set(grid.Point{X: 12, Y: 1}, wall)
+	for i, a := range s[:11] {
+		g.Set(grid.Point{X: i + 1, Y: 1}, strings.IndexByte(chars, a.String()[0]))
+	}
+
+	for y := 2; y < depth+2; y++ {
+		for x := 0; x < g.Width; x++ {
+			if y == 2 || (x >= 2 && x <= 10) {
+				g.Set(grid.Point{X: x, Y: y}, wall)
+			}
+		}
+		for _, a := range []Amphipod{A, B, C, D} {
+			data := r.amphipodData[a]
+			room := s[data.destIndexes[y-2]]
+			g.Set(grid.Point{X: data.hallIndex + 1, Y: y}, strings.IndexByte(chars, room.String()[0]))
+		}
+	}
+
+	return g
+}
+
+var (
+	topRoomSyntax    = regexp.MustCompile(`^###([ABCD])#([ABCD])#([ABCD])#([ABCD])###$`)
+	bottomRoomSyntax = regexp.MustCompile(`^  #([ABCD])#([ABCD])#([ABCD])#([ABCD])#$`)
+)
+
+// Parse reads the burrow diagram, taking the amphipods from the two rows of rooms. The
+// hallway must start empty, and there must be two of each kind of amphipod.
+func Parse(input io.Reader) (*Puzzle, error) {
+	p := &Puzzle{}
+	scanner := parse.NewScanner(input)
+
+	for _, want := range []string{"#############", "#...........#"} {
+		line, err := scanner.Next()
+		if err != nil {
+			return nil, err
+		}
+		if line.Text != want {
+			return nil, line.Errorf("%w: want %q", parse.ErrSyntax, want)
+		}
+	}
+
+	counts := make(map[Amphipod]int)
+	rows := []struct {
+		syntax  *regexp.Regexp
+		want    string
+		indexes []int
+	}{
+		{topRoomSyntax, `"###A#B#C#D###"`, []int{11, 15, 19, 23}},
+		{bottomRoomSyntax, `"  #A#B#C#D#"`, []int{12, 16, 20, 24}},
+	}
+	for _, row := range rows {
+		line, err := scanner.Next()
+		if err != nil {
+			return nil, err
+		}
+		fields, err := line.Match(row.syntax, row.want)
+		if err != nil {
+			return nil, err
+		}
+
+		for i, f := range fields {
+			a := Amphipod(f.Text[0])
+			if counts[a]++; counts[a] > 2 {
+				return nil, f.Errorf("%w: want two of each amphipod", parse.ErrSyntax)
+			}
+			p.Start[row.indexes[i]] = a
+		}
+	}
+
+	line, err := scanner.Next()
+	if err != nil {
+		return nil, err
+	}
+	if want := "  #########"; line.Text != want {
+		return nil, line.Errorf("%w: want %q", parse.ErrSyntax, want)
+	}
+
+	p.Start[13] = DIRT
+	p.Start[14] = DIRT
+	p.Start[17] = DIRT
+	p.Start[18] = DIRT
+	p.Start[21] = DIRT
+	p.Start[22] = DIRT
+	p.Start[25] = DIRT
+	p.Start[26] = DIRT
+
+	if err := scanner.End(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func newBurrow() *burrow {
+	r := &burrow{}
+
+	r.amphipodData = map[Amphipod]*AmphipodData{
+		A: {
+			hallIndex: 2,
+			destIndexes: []int{11, 12, 13, 14},
+			energy: 1,
+		},
+		B: {
+			hallIndex: 4,
+			destIndexes: []int{15, 16, 17, 18},
+			energy: 10,
+		},
+		C: {
+			hallIndex: 6,
+			destIndexes: []int{19, 20, 21, 22},
+			energy: 100,
+		},
+		D: {
+			hallIndex: 8,
+			destIndexes: []int{23, 24, 25, 26},
+			energy: 1000,
+		},
+	}
+
+	r.roomHallIndexes = make(map[int]int)
+	for _, data := range r.amphipodData {
+		for _, i := range data.destIndexes {
+			r.roomHallIndexes[i] = data.hallIndex
+		}
+	}
+
+	return r
+}
+
+var _ challenge.DailyChallenge = &Runner{}
+var _ challenge.ContextChallenge = &Runner{}
+var _ challenge.PhasedChallenge = &Runner{}
+var _ trace.Traced = &Runner{}
+var _ viz.Animator = &Runner{}
+
+func init() {
+	challenge.Register(2021, 23, func() challenge.DailyChallenge {
+		return &Runner{}
+	})
+}
@@ -1,13 +1,15 @@
 package day24
 
 import (
-	"bufio"
+	"context"
 	"errors"
 	"fmt"
 	"github.com/ryderlewis/aoc2021/pkg/challenge"
+	"github.com/ryderlewis/aoc2021/pkg/parse"
+	"github.com/ryderlewis/aoc2021/pkg/trace"
 	"io"
+	"regexp"
 	"strconv"
-	"strings"
 )
 
 type Var byte
@@ -55,21 +57,37 @@ type State struct {
 	remaining, z int
 }
 
+// Puzzle is the MONAD program
+type Puzzle struct {
+	Instructions []*Instruction
+}
+
 type Runner struct {
-	instructions []*Instruction
+	puzzle *Puzzle
+	trace  *trace.Tracer
 }
 
 var _ challenge.DailyChallenge = &Runner{}
+var _ challenge.ContextChallenge = &Runner{}
+var _ challenge.PhasedChallenge = &Runner{}
+var _ trace.Traced = &Runner{}
+
+func init() {
+	challenge.Register(2021, 24, func() challenge.DailyChallenge {
+		return &Runner{}
+	})
+}
 
-// buildInstructionSets returns the lowest and highest possible solutions to the problem
-func (r *Runner) buildInstructionSets() []*InstructionSet {
+// buildInstructionSets returns the lowest and highest possible solutions to the problem.
+// It stops early, returning nil, once ctx is done.
+func (p *Puzzle) buildInstructionSets(ctx context.Context, t *trace.Tracer) []*InstructionSet {
 	// work instructions one input at a time
 	instructionSets := make([]*InstructionSet, 0)
 	startIndex := 0
-	for i := 1; i < len(r.instructions); i++ {
-		if r.instructions[i].operator == INP {
+	for i := 1; i < len(p.Instructions); i++ {
+		if p.Instructions[i].operator == INP {
 			instructionSets = append(instructionSets, &InstructionSet{
-				instructions: r.instructions[startIndex:i],
+				instructions: p.Instructions[startIndex:i],
 				targetZVals: make(map[int]bool),
 			})
 			startIndex = i
@@ -77,7 +95,7 @@ func (r *Runner) buildInstructionSets() []*InstructionSet {
 	}
 	// assumes final instruction is not an input
 	instructionSets = append(instructionSets, &InstructionSet{
-		instructions: r.instructions[startIndex:],
+		instructions: p.Instructions[startIndex:],
 		targetZVals: make(map[int]bool),
 	})
 
@@ -100,8 +118,11 @@ func (r *Runner) buildInstructionSets() []*InstructionSet {
 		nextTargetZVals = make(map[int]bool)
 
 		for z := MINZTOCHECK; z <= MAXZTOCHECK; z++ {
+			if ctx.Err() != nil {
+				return nil
+			}
 			for inp := 1; inp <= 9; inp++ {
-				if vals, err := r.run(instructionSet.instructions, inp, [3]int{0, 0, z}); err == nil {
+				if vals, err := run(instructionSet.instructions, inp, [3]int{0, 0, z}); err == nil {
 					if _, ok := instructionSet.targetZVals[vals[2]]; ok {
 						nextTargetZVals[z] = true
 						if z > maxTargetZ {
@@ -113,11 +134,14 @@ func (r *Runner) buildInstructionSets() []*InstructionSet {
 		}
 	}
 
-	fmt.Printf("max target z: %d\n", maxTargetZ)
+	t.Infof("Max target z: %d", maxTargetZ)
 	return instructionSets
 }
 
-func (r *Runner) findFirstSolution(instructionSets []*InstructionSet, vars[3]int, highest bool) string {
+func findFirstSolution(ctx context.Context, instructionSets []*InstructionSet, vars[3]int, highest bool) string {
+	if ctx.Err() != nil {
+		return ""
+	}
 	inputsRemaining := len(instructionSets)-1
 	solution := ""
 
@@ -129,7 +153,7 @@ func (r *Runner) findFirstSolution(instructionSets []*InstructionSet, vars[3]int
 	}
 
 	for inp := firstInput; inp != lastInput; inp += delta {
-		if vals, err := r.run(instructionSets[0].instructions, inp, vars); err == nil {
+		if vals, err := run(instructionSets[0].instructions, inp, vars); err == nil {
 			if _, ok := instructionSets[0].targetZVals[vals[2]]; !ok {
 				continue
 			}
@@ -140,7 +164,7 @@ func (r *Runner) findFirstSolution(instructionSets []*InstructionSet, vars[3]int
 				break
 			} else {
 				// recursively solve
-				if recur := r.findFirstSolution(instructionSets[1:], vals, highest); recur != "" {
+				if recur := findFirstSolution(ctx, instructionSets[1:], vals, highest); recur != "" {
 					solution = strconv.Itoa(inp) + recur
 					break
 				}
@@ -152,25 +176,74 @@ func (r *Runner) findFirstSolution(instructionSets []*InstructionSet, vars[3]int
 }
 
 func (r *Runner) Challenge1(input io.Reader) (string, error) {
-	if err := r.readInput(input); err != nil {
+	return r.Challenge1Context(context.Background(), input)
+}
+
+func (r *Runner) Challenge1Context(ctx context.Context, input io.Reader) (string, error) {
+	if err := r.Load(input); err != nil {
 		return "", err
 	}
 
-	instructionSets := r.buildInstructionSets()
-	return r.findFirstSolution(instructionSets, [3]int{}, true), nil
+	return solve(ctx, r.puzzle, true, r.trace)
 }
 
 func (r *Runner) Challenge2(input io.Reader) (string, error) {
+	return r.Challenge2Context(context.Background(), input)
+}
+
+func (r *Runner) Challenge2Context(ctx context.Context, input io.Reader) (string, error) {
 	// updated part 1 to return both solutions
-	if err := r.readInput(input); err != nil {
+	if err := r.Load(input); err != nil {
 		return "", err
 	}
 
-	instructionSets := r.buildInstructionSets()
-	return r.findFirstSolution(instructionSets, [3]int{}, false), nil
+	return solve(ctx, r.puzzle, false, r.trace)
+}
+
+func (r *Runner) Load(input io.Reader) (err error) {
+	r.puzzle, err = Parse(input)
+	return err
+}
+
+func (r *Runner) SetTracer(t *trace.Tracer) {
+	r.trace = t
+}
+
+func (r *Runner) Solve1() (string, error) {
+	return solve(context.Background(), r.puzzle, true, r.trace)
+}
+
+func (r *Runner) Solve2() (string, error) {
+	return solve(context.Background(), r.puzzle, false, r.trace)
 }
 
-func (r *Runner) run(instructions []*Instruction, input int, v [3]int) ([3]int, error) {
+// Part1 finds the largest model number accepted by MONAD
+func Part1(p *Puzzle) (string, error) {
+	return solve(context.Background(), p, true, nil)
+}
+
+// Part2 finds the smallest model number accepted by MONAD
+func Part2(p *Puzzle) (string, error) {
+	return solve(context.Background(), p, false, nil)
+}
+
+func solve(ctx context.Context, p *Puzzle, highest bool, t *trace.Tracer) (string, error) {
+	instructionSets := p.buildInstructionSets(ctx, t)
+	solution := ""
+	if instructionSets != nil {
+		solution = findFirstSolution(ctx, instructionSets, [3]int{}, highest)
+	}
+	if ctx.Err() != nil {
+		return "", challenge.Aborted(ctx)
+	}
+	if solution == "" {
+		return "", fmt.Errorf("No model number is accepted")
+	}
+
+	return solution, nil
+}
+
+func run(instructions []*Instruction, input int, v [3]int) ([3]int, error) {
 	vars := map[Var]int{
 		W: 0,
 		X: v[0],
@@ -195,17 +268,14 @@ func (r *Runner) run(instructions []*Instruction, input int, v [3]int) ([3]int,
 			vars[inst.operand1] *= val
 		case DIV:
 			if val == 0 {
-				// fmt.Printf("DIVERR: %d / %d\n", vars[inst.operand1], val)
 				return [3]int{}, DIVERR
 			}
 			vars[inst.operand1] /= val
 		case MOD:
 			if val <= 0 {
-				// fmt.Printf("MODERR: %d %% %d\n", vars[inst.operand1], val)
 				return [3]int{}, MODERR
 			}
 			if vars[inst.operand1] < 0 {
-				// fmt.Printf("MODERR: %d %% %d\n", vars[inst.operand1], val)
 				return [3]int{}, MODERR
 			}
 			vars[inst.operand1] %= val
@@ -223,26 +293,51 @@ func (r *Runner) run(instructions []*Instruction, input int, v [3]int) ([3]int,
 	return [3]int{vars[X], vars[Y], vars[Z]}, nil
 }
 
-func (r *Runner) readInput(input io.Reader) error {
-	scanner := bufio.NewScanner(input)
+var instructionSyntax = regexp.MustCompile(`^(?:(inp) ([wxyz])|(add|mul|div|mod|eql) ([wxyz]) ([wxyz]|-?\d+))$`)
+
+// Parse reads one ALU instruction per line. There must be at least one inp instruction,
+// each starting an instruction set.
+func Parse(input io.Reader) (*Puzzle, error) {
+	p := &Puzzle{}
+	scanner := parse.NewScanner(input)
+	inputs := 0
 
 	for scanner.Scan() {
-		tokens := strings.Fields(scanner.Text())
-		instruction := &Instruction{
-			operator: tokens[0],
-			operand1: Var(tokens[1][0]),
+		fields, err := scanner.Field().Match(instructionSyntax, `an instruction such as "inp w" or "add x -1"`)
+		if err != nil {
+			return nil, err
 		}
-		if len(tokens) > 2 {
-			switch tokens[2] {
+
+		var instruction *Instruction
+		if fields[0].Text == INP {
+			inputs++
+			instruction = &Instruction{
+				operator: INP,
+				operand1: Var(fields[1].Text[0]),
+			}
+		} else {
+			instruction = &Instruction{
+				operator: fields[2].Text,
+				operand1: Var(fields[3].Text[0]),
+			}
+			switch fields[4].Text {
 			case "w", "x", "y", "z":
-				instruction.operand2 = Var(tokens[2][0])
+				instruction.operand2 = Var(fields[4].Text[0])
 			default:
 				instruction.operand2 = LITERAL
-				instruction.literal, _ = strconv.Atoi(tokens[2])
+				if instruction.literal, err = fields[4].Int(); err != nil {
+					return nil, err
+				}
 			}
 		}
-		r.instructions = append(r.instructions, instruction)
+		p.Instructions = append(p.Instructions, instruction)
 	}
 
-	return nil
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if inputs == 0 {
+		return nil, scanner.Truncated("an inp instruction")
+	}
+	return p, nil
 }
@@ -0,0 +1,195 @@
+package day25
+
+import (
+	"fmt"
+	"github.com/ryderlewis/aoc2021/pkg/challenge"
+	"github.com/ryderlewis/aoc2021/pkg/grid"
+	"github.com/ryderlewis/aoc2021/pkg/parse"
+	"github.com/ryderlewis/aoc2021/pkg/viz"
+	"io"
+	"strconv"
+)
+
+const (
+	// values at start of each round
+	EMPTY = iota // empty square
+	EAST         // east-facing cucumber
+	SOUTH        // south-facing cucumber
+
+	// temporary values
+	MOVEDEAST    // occupied by a cucumber that has already moved east one step
+	MOVEDSOUTH   // occupied by a cucumber that has already moved south one step
+	VACATEDEAST  // square that was occupied by an east-facing cucumber
+	VACATEDSOUTH // square that was occupied by a south-facing cucumber
+)
+
+// squares are the characters for the values at the start of each round
+const squares = ".>v"
+
+// look shows the sea floor, highlighting the cucumbers that just moved and the squares
+// they left
+var look = viz.Style{Max: SOUTH, Chars: squares}
+
+// Puzzle is the starting positions of the sea cucumbers
+type Puzzle struct {
+	Board *grid.Grid
+}
+
+// herd is the state of the sea cucumbers as they move
+type herd struct {
+	board *grid.Grid
+}
+
+type Runner struct {
+	puzzle *Puzzle
+}
+
+var _ challenge.DailyChallenge = &Runner{}
+var _ challenge.PhasedChallenge = &Runner{}
+var _ viz.Animator = &Runner{}
+
+func init() {
+	challenge.Register(2021, 25, func() challenge.DailyChallenge {
+		return &Runner{}
+	})
+}
+
+func (r *Runner) Challenge1(input io.Reader) (string, error) {
+	if err := r.Load(input); err != nil {
+		return "", err
+	}
+
+	return r.Solve1()
+}
+
+func (r *Runner) Challenge2(input io.Reader) (string, error) {
+	if err := r.Load(input); err != nil {
+		return "", err
+	}
+
+	return r.Solve2()
+}
+
+func (r *Runner) Challenge1Viz(input io.Reader, rec *viz.Recorder) (string, error) {
+	if err := r.Load(input); err != nil {
+		return "", err
+	}
+
+	return part1(r.puzzle, rec)
+}
+
+func (r *Runner) Challenge2Viz(input io.Reader, rec *viz.Recorder) (string, error) {
+	return r.Challenge2(input)
+}
+
+func (r *Runner) Load(input io.Reader) (err error) {
+	r.puzzle, err = Parse(input)
+	return err
+}
+
+func (r *Runner) Solve1() (string, error) {
+	return Part1(r.puzzle)
+}
+
+func (r *Runner) Solve2() (string, error) {
+	return Part2(r.puzzle)
+}
+
+// Part1 counts the steps taken until no sea cucumbers move. Some herds wrap around the
+// sea floor forever, which is an error.
+func Part1(p *Puzzle) (string, error) {
+	return part1(p, nil)
+}
+
+// part1 moves the herd until it stops, recording the sea floor after every step
+func part1(p *Puzzle, rec *viz.Recorder) (string, error) {
+	r := p.herd()
+	seen := map[string]bool{r.state(): true}
+	rec.Frame(r.board, look)
+
+	steps := 1
+	for r.move() {
+		rec.Frame(r.board, look)
+		state := r.state()
+		if seen[state] {
+			return "", fmt.Errorf("Sea cucumbers repeat after %d steps without stopping", steps)
+		}
+		seen[state] = true
+		steps++
+	}
+
+	return strconv.Itoa(steps), nil
+}
+
+// Part2 has no puzzle to solve
+func Part2(p *Puzzle) (string, error) {
+	return strconv.Itoa(0), nil
+}
+
+// herd returns a copy of the starting positions that can be moved
+func (p *Puzzle) herd() *herd {
+	return &herd{board: p.Board.Copy()}
+}
+
+// state returns the squares in order, so that repeated states can be spotted
+func (r *herd) state() string {
+	return r.board.Render(squares)
+}
+
+func (r *herd) move() bool {
+	moved := false
+	points := r.board.Points()
+
+	// move east-facing cucumbers
+	for _, pos := range points {
+		// see if an east-facing cucumber in this square moves
+		if r.board.Get(pos) == EAST {
+			right, _ := r.board.Step(pos, grid.Point{X: 1})
+			if r.board.Get(right) == EMPTY {
+				r.board.Set(pos, VACATEDEAST)
+				r.board.Set(right, MOVEDEAST)
+				moved = true
+			}
+		}
+	}
+
+	// move south-facing cucumbers
+	for _, pos := range points {
+		// see if a south-facing cucumber in this square moves
+		if r.board.Get(pos) == SOUTH {
+			// see if a south-facing cucumber moves down
+			down, _ := r.board.Step(pos, grid.Point{Y: 1})
+			switch r.board.Get(down) {
+			case EMPTY, VACATEDEAST:
+				r.board.Set(pos, VACATEDSOUTH)
+				r.board.Set(down, MOVEDSOUTH)
+				moved = true
+			}
+		}
+	}
+
+	// clean up the board
+	for _, pos := range points {
+		switch r.board.Get(pos) {
+		case VACATEDEAST, VACATEDSOUTH:
+			r.board.Set(pos, EMPTY)
+		case MOVEDEAST:
+			r.board.Set(pos, EAST)
+		case MOVEDSOUTH:
+			r.board.Set(pos, SOUTH)
+		}
+	}
+
+	return moved
+}
+
+// Parse reads one row of the sea floor per line. Every row must be the same length.
+func Parse(input io.Reader) (*Puzzle, error) {
+	board, err := grid.Read(parse.NewScanner(input), squares, "a row of the sea floor")
+	if err != nil {
+		return nil, err
+	}
+	board.Wrap = true
+
+	return &Puzzle{Board: board}, nil
+}
@@ -0,0 +1,34 @@
+// Package y2021 links every day's challenge from 2021 into a binary. Importing it for its
+// side effects registers them with the challenge registry under the year 2021.
+//
+// Each year's days live in their own packages under pkg/yYYYY/dayNN, registering
+// themselves for that year, with a pkg/yYYYY package like this one importing them all.
+package y2021
+
+import (
+	_ "github.com/ryderlewis/aoc2021/pkg/y2021/day01"
+	_ "github.com/ryderlewis/aoc2021/pkg/y2021/day02"
+	_ "github.com/ryderlewis/aoc2021/pkg/y2021/day03"
+	_ "github.com/ryderlewis/aoc2021/pkg/y2021/day04"
+	_ "github.com/ryderlewis/aoc2021/pkg/y2021/day05"
+	_ "github.com/ryderlewis/aoc2021/pkg/y2021/day06"
+	_ "github.com/ryderlewis/aoc2021/pkg/y2021/day07"
+	_ "github.com/ryderlewis/aoc2021/pkg/y2021/day08"
+	_ "github.com/ryderlewis/aoc2021/pkg/y2021/day09"
+	_ "github.com/ryderlewis/aoc2021/pkg/y2021/day10"
+	_ "github.com/ryderlewis/aoc2021/pkg/y2021/day11"
+	_ "github.com/ryderlewis/aoc2021/pkg/y2021/day12"
+	_ "github.com/ryderlewis/aoc2021/pkg/y2021/day13"
+	_ "github.com/ryderlewis/aoc2021/pkg/y2021/day14"
+	_ "github.com/ryderlewis/aoc2021/pkg/y2021/day15"
+	_ "github.com/ryderlewis/aoc2021/pkg/y2021/day16"
+	_ "github.com/ryderlewis/aoc2021/pkg/y2021/day17"
+	_ "github.com/ryderlewis/aoc2021/pkg/y2021/day18"
+	_ "github.com/ryderlewis/aoc2021/pkg/y2021/day19"
+	_ "github.com/ryderlewis/aoc2021/pkg/y2021/day20"
+	_ "github.com/ryderlewis/aoc2021/pkg/y2021/day21"
+	_ "github.com/ryderlewis/aoc2021/pkg/y2021/day22"
+	_ "github.com/ryderlewis/aoc2021/pkg/y2021/day23"
+	_ "github.com/ryderlewis/aoc2021/pkg/y2021/day24"
+	_ "github.com/ryderlewis/aoc2021/pkg/y2021/day25"
+)